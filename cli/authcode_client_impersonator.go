@@ -14,8 +14,10 @@ type AuthcodeClientImpersonator struct {
 	config             uaa.Config
 	ClientId           string
 	ClientSecret       string
+	ClientAuth         uaa.ClientAuthentication
 	TokenFormat        string
 	Scope              string
+	Origin             string
 	UaaBaseUrl         string
 	Port               int
 	Log                Logger
@@ -72,7 +74,7 @@ func (aci AuthcodeClientImpersonator) Start() {
 		go aci.AuthCallbackServer.Start(urlValues)
 		values := <-urlValues
 		code := values.Get("code")
-		tokenRequester := uaa.AuthorizationCodeClient{ClientId: aci.ClientId, ClientSecret: aci.ClientSecret}
+		tokenRequester := uaa.AuthorizationCodeClient{ClientId: aci.ClientId, ClientSecret: aci.ClientSecret, ClientAuth: aci.ClientAuth}
 		aci.Log.Infof("Calling UAA /oauth/token to exchange code %v for an access token", code)
 		resp, err := tokenRequester.RequestToken(aci.httpClient, aci.config, uaa.TokenFormat(aci.TokenFormat), code, aci.redirectUri())
 		if err != nil {
@@ -88,8 +90,15 @@ func (aci AuthcodeClientImpersonator) Authorize() {
 	requestValues.Add("response_type", "code")
 	requestValues.Add("client_id", aci.ClientId)
 	requestValues.Add("redirect_uri", aci.redirectUri())
+	if aci.Scope != "" {
+		requestValues.Add("scope", aci.Scope)
+	}
+	if aci.Origin != "" {
+		requestValues.Add("login_hint", uaa.LoginHint(aci.Origin))
+	}
 
-	authUrl, err := utils.BuildUrl(aci.config.GetActiveTarget().BaseUrl, "/oauth/authorize")
+	target := aci.config.GetActiveTarget()
+	authUrl, err := utils.BuildUrl(target.BaseUrl, target.AuthorizationEndpoint())
 	if err != nil {
 		aci.Log.Error("Something went wrong while building the authorization URL.")
 		os.Exit(1)
@@ -105,7 +105,16 @@ var _ = Describe("AuthcodeClientImpersonator", func() {
 
 			impersonator.Authorize()
 
-			Expect(launcher.TargetUrl).To(Equal(uaaServer.URL() + "/oauth/authorize?client_id=authcodeId&redirect_uri=http%3A%2F%2Flocalhost%3A8080&response_type=code"))
+			Expect(launcher.TargetUrl).To(Equal(uaaServer.URL() + "/oauth/authorize?client_id=authcodeId&redirect_uri=http%3A%2F%2Flocalhost%3A8080&response_type=code&scope=openid"))
+		})
+
+		It("passes the origin of the identity provider as login_hint", func() {
+			impersonator = NewAuthcodeClientImpersonator(httpClient, config, "authcodeId", "authcodesecret", "jwt", "openid scim.read", 8080, logger, launcher.Run)
+			impersonator.Origin = "ldap"
+
+			impersonator.Authorize()
+
+			Expect(launcher.TargetUrl).To(Equal(uaaServer.URL() + "/oauth/authorize?client_id=authcodeId&login_hint=%7B%22origin%22%3A%22ldap%22%7D&redirect_uri=http%3A%2F%2Flocalhost%3A8080&response_type=code&scope=openid+scim.read"))
 		})
 	})
 })
@@ -16,15 +16,16 @@ type ClientImpersonator interface {
 }
 
 type ImplicitClientImpersonator struct {
-	ClientId           string
-	TokenFormat        string
-	Scope              string
-	UaaBaseUrl         string
-	Port               int
-	Log                Logger
-	AuthCallbackServer CallbackServer
-	BrowserLauncher    func(string) error
-	done               chan uaa.TokenResponse
+	ClientId              string
+	TokenFormat           string
+	Scope                 string
+	UaaBaseUrl            string
+	AuthorizationEndpoint string
+	Port                  int
+	Log                   Logger
+	AuthCallbackServer    CallbackServer
+	BrowserLauncher       func(string) error
+	done                  chan uaa.TokenResponse
 }
 
 const CallbackCSS = `<style>
@@ -105,7 +106,11 @@ func (ici ImplicitClientImpersonator) Authorize() {
 	requestValues.Add("token_format", ici.TokenFormat)
 	requestValues.Add("redirect_uri", fmt.Sprintf("http://localhost:%v", ici.Port))
 
-	authUrl, err := utils.BuildUrl(ici.UaaBaseUrl, "/oauth/authorize")
+	authorizationEndpoint := ici.AuthorizationEndpoint
+	if authorizationEndpoint == "" {
+		authorizationEndpoint = "/oauth/authorize"
+	}
+	authUrl, err := utils.BuildUrl(ici.UaaBaseUrl, authorizationEndpoint)
 	if err != nil {
 		ici.Log.Error("Something went wrong while building the authorization URL.")
 		os.Exit(1)
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
 	"github.com/fatih/color"
 	"golang.org/x/crypto/ssh/terminal"
@@ -38,10 +37,28 @@ func (ip InteractivePrompt) Get() (string, error) {
 	prompt := color.CyanString(ip.Prompt + ": ")
 	fmt.Fprint(InteractiveOutput, prompt)
 
-	reader := bufio.NewReader(InteractiveInput)
-	val, err := reader.ReadString('\n')
+	val, err := readLine(InteractiveInput)
 	if err != nil {
 		return "", err
 	}
 	return strings.TrimSpace(val), nil
 }
+
+// readLine reads up to and including the next newline one byte at a time, so
+// that input meant for later prompts is not buffered away.
+func readLine(input io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := input.Read(b)
+		if n > 0 {
+			line = append(line, b[0])
+			if b[0] == '\n' {
+				return string(line), nil
+			}
+		}
+		if err != nil {
+			return string(line), err
+		}
+	}
+}
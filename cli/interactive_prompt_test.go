@@ -41,6 +41,17 @@ var _ = Describe("Interactive inputs", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(input).To(Equal("woodstock"))
 		})
+
+		It("leaves the input for later prompts unread", func() {
+			inbuf.WriteString("woodstock\nsnoopy\n")
+
+			first, _ := InteractivePrompt{Prompt: "Username"}.Get()
+			second, err := InteractivePrompt{Prompt: "Tenant"}.Get()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first).To(Equal("woodstock"))
+			Expect(second).To(Equal("snoopy"))
+		})
 	})
 
 	Describe("InteractiveSecret", func() {
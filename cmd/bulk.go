@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"errors"
+	"sync"
+)
+
+// forEachConcurrently calls work with every index below n, running at most
+// concurrency calls at the same time, and returns once all calls are done.
+func forEachConcurrently(n, concurrency int, work func(index int)) {
+	pending := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range pending {
+				work(index)
+			}
+		}()
+	}
+
+	for index := 0; index < n; index++ {
+		pending <- index
+	}
+	close(pending)
+	wg.Wait()
+}
+
+func validateConcurrency(concurrency int) error {
+	if concurrency < 1 {
+		return errors.New("The concurrency must be at least 1.")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"github.com/spf13/cobra"
+)
+
+// contextUserId returns the id of the user the active context's token was
+// issued to, from the token itself or, for opaque tokens, from /userinfo.
+func contextUserId(httpClient *http.Client, config uaa.Config) (string, error) {
+	userId := ""
+	if jwt, err := uaa.ParseJwt(config.GetActiveContext().AccessToken); err == nil {
+		userId = jwt.StringClaim("user_id")
+	} else if info, err := uaa.Me(httpClient, config); err == nil {
+		userId = info.UserId
+	}
+
+	if userId == "" {
+		return "", errors.New("The active context does not belong to a user. Get a token for the user whose password should change, e.g. with uaa login.")
+	}
+	return userId, nil
+}
+
+func ChangePasswordCmd(httpClient *http.Client, config uaa.Config, log cli.Logger, oldPassword, password string, generate bool) error {
+	userId, err := contextUserId(httpClient, config)
+	if err != nil {
+		return err
+	}
+
+	if oldPassword == "" {
+		oldPassword, err = promptForSecret("Current password")
+		if err != nil {
+			return err
+		}
+	}
+	password, err = newPassword(httpClient, config, log, password, generate)
+	if err != nil {
+		return err
+	}
+
+	um := uaa.UserManager{httpClient, config}
+	if err := um.SetPassword(userId, password, oldPassword); err != nil {
+		return errors.New(describeError(err))
+	}
+
+	log.Info("Your password was changed.")
+	if generate {
+		printGeneratedPassword(log, password)
+	}
+	return nil
+}
+
+func ChangePasswordValidations(cfg uaa.Config, password string, generate bool) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	return validatePasswordSource(password, generate)
+}
+
+var changePasswordCmd = &cobra.Command{
+	Use:   "change-password",
+	Short: "Change the password of the user of the active context",
+	Long:  help.ChangePassword(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(ChangePasswordValidations(GetSavedConfig(), userPassword, generatePassword), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		err := ChangePasswordCmd(GetHttpClient(), cfg, log, oldPassword, userPassword, generatePassword)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(changePasswordCmd)
+	changePasswordCmd.Annotations = make(map[string]string)
+	changePasswordCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	changePasswordCmd.Flags().StringVarP(&oldPassword, "old-password", "", "", "the current password. You are prompted for it when it is not given")
+	changePasswordCmd.Flags().StringVarP(&userPassword, "password", "p", "", "the new password. You are prompted for it when it is not given")
+	changePasswordCmd.Flags().BoolVarP(&generatePassword, "generate", "", false, "generate a random password satisfying the zone's password policy and print it")
+}
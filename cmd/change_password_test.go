@@ -0,0 +1,98 @@
+package cmd_test
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ChangePassword", func() {
+	token := func(claims string) string {
+		encode := func(segment string) string {
+			return base64.RawURLEncoding.EncodeToString([]byte(segment))
+		}
+		return encode(`{"alg":"RS256"}`) + "." + encode(claims) + "." + encode("signature")
+	}
+	userToken := token(`{"user_id":"abcdef","user_name":"woodstock","client_id":"cf"}`)
+
+	writeConfigWithToken := func(accessToken string) {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken(accessToken))
+		config.WriteConfig(cfg)
+	}
+
+	BeforeEach(func() {
+		writeConfigWithToken(userToken)
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("change-password", "--old-password", "0ld", "--password", "n3w")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("does not take both --password and --generate", func() {
+			session := runCommand("change-password", "--old-password", "0ld", "--password", "n3w", "--generate")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Use either --password or --generate."))
+		})
+	})
+
+	It("changes the password of the user in the token", func() {
+		server.RouteToHandler("PUT", "/Users/abcdef/password", CombineHandlers(
+			VerifyHeaderKV("Authorization", "bearer "+userToken),
+			VerifyJSON(`{"password": "n3w", "oldPassword": "0ld"}`),
+			RespondWith(http.StatusOK, `{"status": "ok"}`),
+		))
+
+		session := runCommand("change-password", "--old-password", "0ld", "--password", "n3w")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("Your password was changed."))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("looks up the user of opaque tokens", func() {
+		writeConfigWithToken("opaque_token")
+		server.RouteToHandler("GET", "/userinfo", RespondWith(http.StatusOK, `{"user_id": "abcdef", "user_name": "woodstock"}`))
+		server.RouteToHandler("PUT", "/Users/abcdef/password", RespondWith(http.StatusOK, `{"status": "ok"}`))
+
+		session := runCommand("change-password", "--old-password", "0ld", "--password", "n3w")
+
+		Eventually(session).Should(Exit(0))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("refuses tokens which do not belong to a user", func() {
+		writeConfigWithToken(token(`{"client_id":"admin","scope":["uaa.admin"]}`))
+
+		session := runCommand("change-password", "--old-password", "0ld", "--password", "n3w")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The active context does not belong to a user."))
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("shows why the UAA rejected the change", func() {
+		server.RouteToHandler("PUT", "/Users/abcdef/password", RespondWith(http.StatusUnauthorized,
+			`{"error": "unauthorized", "error_description": "Old password is incorrect"}`))
+
+		session := runCommand("change-password", "--old-password", "wrong", "--password", "n3w")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("Old password is incorrect"))
+	})
+})
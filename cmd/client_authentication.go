@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+)
+
+// Client authentication flags
+var (
+	clientAuthMethod = string(uaa.CLIENT_SECRET_POST)
+	privateKeyPath   string
+	keyId            string
+)
+
+func availableClientAuthMethods() []string {
+	return []string{
+		string(uaa.CLIENT_SECRET_POST),
+		string(uaa.CLIENT_SECRET_BASIC),
+		string(uaa.PRIVATE_KEY_JWT),
+	}
+}
+
+// addClientAuthenticationFlags registers the client authentication flags with
+// the method used when --auth-method is not given. Each command keeps its own
+// --auth-method value, which is copied to clientAuthMethod before the command's
+// PreRun, so that commands with different defaults do not affect each other.
+func addClientAuthenticationFlags(cmd *cobra.Command, defaultMethod uaa.ClientAuthMethod) {
+	method := new(string)
+	cmd.Flags().StringVarP(method, "auth-method", "", string(defaultMethod), "how the client authenticates to the token endpoint, one of "+utils.StringSliceStringifier(availableClientAuthMethods()))
+	preRun := cmd.PreRun
+	cmd.PreRun = func(cmd *cobra.Command, args []string) {
+		clientAuthMethod = *method
+		if preRun != nil {
+			preRun(cmd, args)
+		}
+	}
+	cmd.Flags().StringVarP(&privateKeyPath, "private-key", "", "", "path to a PEM encoded RSA or EC private key used to sign the client assertion for private_key_jwt")
+	cmd.Flags().StringVarP(&keyId, "key-id", "", "", "key id (kid) of the private key registered for the client")
+}
+
+func validateClientAuthMethod() error {
+	if !utils.Contains(availableClientAuthMethods(), clientAuthMethod) {
+		return fmt.Errorf(`The client authentication method "%v" is unknown. Available methods: %v`, clientAuthMethod, utils.StringSliceStringifier(availableClientAuthMethods()))
+	}
+	if uaa.ClientAuthMethod(clientAuthMethod) == uaa.PRIVATE_KEY_JWT && privateKeyPath == "" {
+		return MissingArgumentWithExplanationError("private-key", "A private key is required for the private_key_jwt authentication method.")
+	}
+	return nil
+}
+
+// validateClientAuthentication checks the client authentication flags and
+// requires a client secret unless the client signs its own assertion.
+func validateClientAuthentication(clientSecret string) error {
+	if err := validateClientAuthMethod(); err != nil {
+		return err
+	}
+	if uaa.ClientAuthMethod(clientAuthMethod) != uaa.PRIVATE_KEY_JWT && clientSecret == "" {
+		return MissingArgumentError("client_secret")
+	}
+	return nil
+}
+
+func buildClientAuthentication(clientId, clientSecret string) (uaa.ClientAuthentication, error) {
+	switch uaa.ClientAuthMethod(clientAuthMethod) {
+	case uaa.CLIENT_SECRET_BASIC:
+		return uaa.ClientSecretBasic{ClientId: clientId, ClientSecret: clientSecret}, nil
+	case uaa.PRIVATE_KEY_JWT:
+		pemBytes, err := ioutil.ReadFile(privateKeyPath)
+		if err != nil {
+			return nil, errors.New("The private key could not be read from " + privateKeyPath + ".")
+		}
+		return uaa.NewPrivateKeyJwt(clientId, keyId, pemBytes)
+	}
+	return uaa.ClientSecretPost{ClientId: clientId, ClientSecret: clientSecret}, nil
+}
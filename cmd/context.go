@@ -3,10 +3,34 @@ package cmd
 import (
 	"code.cloudfoundry.org/uaa-cli/cli"
 	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
 	"github.com/spf13/cobra"
 	"os"
+	"time"
 )
 
+var decode bool
+
+// decodedContext adds the decoded tokens of a context to its saved fields.
+type decodedContext struct {
+	uaa.UaaContext
+	DecodedAccessToken *DecodedToken `json:"decoded_access_token,omitempty"`
+	DecodedIdToken     *DecodedToken `json:"decoded_id_token,omitempty"`
+}
+
+func decodeContext(ctx uaa.UaaContext, now time.Time) decodedContext {
+	decoded := decodedContext{UaaContext: ctx}
+	if ctx.AccessToken != "" {
+		accessToken := decodeToken(ctx.AccessToken, now)
+		decoded.DecodedAccessToken = &accessToken
+	}
+	if ctx.IdToken != "" {
+		idToken := decodeToken(ctx.IdToken, now)
+		decoded.DecodedIdToken = &idToken
+	}
+	return decoded
+}
+
 var contextCmd = &cobra.Command{
 	Use:   "context",
 	Short: "See information about the currently active CLI context",
@@ -26,7 +50,10 @@ var contextCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		activeContext := c.GetActiveContext()
+		var activeContext interface{} = c.GetActiveContext()
+		if decode {
+			activeContext = decodeContext(c.GetActiveContext(), time.Now())
+		}
 		err := cli.NewJsonPrinter(log).Print(activeContext)
 		if err != nil {
 			log.Error(err.Error())
@@ -39,4 +66,5 @@ func init() {
 	RootCmd.AddCommand(contextCmd)
 	contextCmd.Annotations = make(map[string]string)
 	contextCmd.Annotations[INTRO_CATEGORY] = "true"
+	contextCmd.Flags().BoolVarP(&decode, "decode", "", false, "decode the header and claims of the access and id tokens")
 }
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+func CreatePasswordResetCodeCmd(um uaa.UserManager, log cli.Logger, username, clientId, redirectUri string) error {
+	// Only users of the internal identity provider have a password to reset.
+	user, err := um.GetByUsername(username, "uaa", "id,userName")
+	if err != nil {
+		return err
+	}
+
+	resetCode, err := um.CreatePasswordResetCode(user.Username, clientId, redirectUri)
+	if err != nil {
+		return errors.New(describeError(err))
+	}
+
+	log.Infof("The password reset code for user %v is %v", utils.Emphasize(user.Username), utils.Emphasize(resetCode.Code))
+	log.Info("It can be used once, for a limited time, e.g. with uaa reset-password CODE.")
+	return nil
+}
+
+func CreatePasswordResetCodeValidations(cfg uaa.Config, args []string) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("The positional argument USERNAME must be specified.")
+	}
+	return nil
+}
+
+var createPasswordResetCodeCmd = &cobra.Command{
+	Use:   "create-password-reset-code USERNAME",
+	Short: "Create a code with which a user's password can be reset",
+	Long:  help.CreatePasswordResetCode(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(CreatePasswordResetCodeValidations(GetSavedConfig(), args), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		um := uaa.UserManager{GetHttpClient(), cfg}
+		err := CreatePasswordResetCodeCmd(um, log, args[0], redirectClientId, redirectUri)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(createPasswordResetCodeCmd)
+	createPasswordResetCodeCmd.Annotations = make(map[string]string)
+	createPasswordResetCodeCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	createPasswordResetCodeCmd.Flags().StringVarP(&redirectClientId, "client_id", "", "", "the client whose redirect URI the user is sent to after the reset")
+	createPasswordResetCodeCmd.Flags().StringVarP(&redirectUri, "redirect_uri", "", "", "where to send the user after the reset. Must be allowed for the client")
+	createPasswordResetCodeCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain of the user")
+}
@@ -0,0 +1,69 @@
+package cmd_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("CreatePasswordResetCode", func() {
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock%22+and+origin+eq+%22uaa%22&attributes=id%2CuserName"),
+			RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock", ID: "abcdef"})),
+		))
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("create-password-reset-code", "woodstock")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a username", func() {
+			session := runCommand("create-password-reset-code")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument USERNAME must be specified."))
+		})
+	})
+
+	It("creates a code for a user of the uaa identity provider", func() {
+		server.RouteToHandler("POST", "/password_resets", CombineHandlers(
+			VerifyRequest("POST", "/password_resets", "client_id=cf&redirect_uri=https%3A%2F%2Fexample.com"),
+			VerifyBody([]byte("woodstock")),
+			RespondWith(http.StatusCreated, `{"code": "yCQL6a0dJr", "user_id": "abcdef"}`),
+		))
+
+		session := runCommand("create-password-reset-code", "woodstock", "--client_id", "cf", "--redirect_uri", "https://example.com")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("The password reset code for user woodstock is yCQL6a0dJr"))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("reports users which cannot be found", func() {
+		server.RouteToHandler("GET", "/Users", RespondWith(http.StatusOK, fixtures.PaginatedResponse()))
+
+		session := runCommand("create-password-reset-code", "woodstock")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("User woodstock not found in origin uaa"))
+	})
+})
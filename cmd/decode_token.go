@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"errors"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+var (
+	decodeIdToken bool
+	decodeFormat  string
+)
+
+type DecodedToken struct {
+	Opaque    bool                   `json:"opaque,omitempty"`
+	Header    map[string]interface{} `json:"header,omitempty"`
+	Claims    map[string]interface{} `json:"claims,omitempty"`
+	Scopes    []string               `json:"scopes,omitempty"`
+	Audience  []string               `json:"audience,omitempty"`
+	IssuedAt  string                 `json:"issued_at,omitempty"`
+	ExpiresAt string                 `json:"expires_at,omitempty"`
+	AuthTime  string                 `json:"auth_time,omitempty"`
+	Expired   bool                   `json:"expired,omitempty"`
+}
+
+func decodeToken(token string, now time.Time) DecodedToken {
+	jwt, err := uaa.ParseJwt(token)
+	if err != nil {
+		return DecodedToken{Opaque: true}
+	}
+
+	decoded := DecodedToken{
+		Header:   jwt.Header,
+		Claims:   jwt.Claims,
+		Scopes:   jwt.Scopes(),
+		Audience: jwt.Audience(),
+	}
+	if iat, ok := jwt.TimeClaim("iat"); ok {
+		decoded.IssuedAt = iat.Format(time.RFC3339)
+	}
+	if exp, ok := jwt.TimeClaim("exp"); ok {
+		decoded.ExpiresAt = exp.Format(time.RFC3339)
+		decoded.Expired = now.After(exp)
+	}
+	if authTime, ok := jwt.TimeClaim("auth_time"); ok {
+		decoded.AuthTime = authTime.Format(time.RFC3339)
+	}
+	return decoded
+}
+
+// tokenUsername returns the user_name claim of a JWT, or "" for opaque tokens
+// and tokens issued to clients.
+func tokenUsername(token string) string {
+	jwt, err := uaa.ParseJwt(token)
+	if err != nil {
+		return ""
+	}
+	return jwt.StringClaim("user_name")
+}
+
+// DecodeTokenCmd prints the decoded token. Opaque tokens are reported as such
+// rather than failing, unless format says the token must be a JWT.
+func DecodeTokenCmd(cfg uaa.Config, printer cli.Printer, token string, idToken bool, format string) error {
+	if token == "" {
+		activeContext := cfg.GetActiveContext()
+		token = activeContext.AccessToken
+		if idToken {
+			token = activeContext.IdToken
+		}
+	}
+
+	decoded := DecodedToken{Opaque: true}
+	if uaa.TokenFormat(format) != uaa.OPAQUE {
+		decoded = decodeToken(token, time.Now())
+	}
+	if decoded.Opaque && uaa.TokenFormat(format) == uaa.JWT {
+		return errors.New("The token is opaque and cannot be decoded locally. Use introspect-token to ask the UAA about it.")
+	}
+	return printer.Print(decoded)
+}
+
+func DecodeTokenValidations(cfg uaa.Config, args []string, idToken bool, format string) error {
+	if format != "" {
+		if err := validateTokenFormatError(format); err != nil {
+			return err
+		}
+	}
+	if len(args) > 0 {
+		if idToken {
+			return errors.New("The --id-token flag cannot be combined with a TOKEN argument.")
+		}
+		return nil
+	}
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if idToken && cfg.GetActiveContext().IdToken == "" {
+		return errors.New("There is no id_token in the active context.")
+	}
+	if cfg.GetActiveContext().AccessToken == "" {
+		return MissingArgumentWithExplanationError("token", "There is no access_token in the active context to decode.")
+	}
+	return nil
+}
+
+var decodeTokenCmd = &cobra.Command{
+	Use:   "decode-token [TOKEN]",
+	Short: "Decode the header and claims of a JWT without contacting the UAA",
+	Long:  help.DecodeToken(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(DecodeTokenValidations(cfg, args, decodeIdToken, decodeFormat), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		var token string
+		if len(args) > 0 {
+			token = args[0]
+		}
+		err := DecodeTokenCmd(cfg, cli.NewJsonPrinter(log), token, decodeIdToken, decodeFormat)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(decodeTokenCmd)
+	decodeTokenCmd.Annotations = make(map[string]string)
+	decodeTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
+	decodeTokenCmd.Flags().BoolVarP(&decodeIdToken, "id-token", "", false, "decode the id_token of the active context instead of its access_token")
+	decodeTokenCmd.Flags().StringVarP(&decodeFormat, "format", "", "", "the format the token was issued in, one of "+availableFormatsStr()+". Detected when not given; jwt fails on opaque tokens")
+}
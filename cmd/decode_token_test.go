@@ -0,0 +1,133 @@
+package cmd_test
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"encoding/base64"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("DecodeToken", func() {
+	encode := func(segment string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(segment))
+	}
+	accessToken := encode(`{"alg":"RS256","kid":"key-1"}`) + "." +
+		encode(`{"scope":["openid","scim.read"],"aud":["scim","openid"],"exp":1500000000,"iat":1499956800,"client_id":"cf"}`) + "." +
+		encode("signature")
+	idToken := encode(`{"alg":"RS256"}`) + "." +
+		encode(`{"aud":"cf","auth_time":1499956700,"user_name":"woodstock"}`) + "." +
+		encode("signature")
+
+	BeforeEach(func() {
+		c := uaa.NewConfigWithServerURL(server.URL())
+		ctx := uaa.NewContextWithToken(accessToken)
+		ctx.ClientId = "cf"
+		ctx.IdToken = idToken
+		c.AddContext(ctx)
+		config.WriteConfig(c)
+	})
+
+	It("decodes the access token of the active context", func() {
+		session := runCommand("decode-token")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(`{
+		  "header": {"alg": "RS256", "kid": "key-1"},
+		  "claims": {
+		    "scope": ["openid", "scim.read"],
+		    "aud": ["scim", "openid"],
+		    "exp": 1500000000,
+		    "iat": 1499956800,
+		    "client_id": "cf"
+		  },
+		  "scopes": ["openid", "scim.read"],
+		  "audience": ["scim", "openid"],
+		  "issued_at": "2017-07-13T14:40:00Z",
+		  "expires_at": "2017-07-14T02:40:00Z",
+		  "expired": true
+		}`))
+	})
+
+	It("decodes the id token of the active context", func() {
+		session := runCommand("decode-token", "--id-token")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say(`"user_name": "woodstock"`))
+		Expect(session.Out).To(Say(`"auth_time": "2017-07-13T14:38:20Z"`))
+	})
+
+	It("decodes a token given as an argument without a context", func() {
+		config.WriteConfig(uaa.NewConfig())
+
+		session := runCommand("decode-token", idToken)
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say(`"audience": \[\s+"cf"\s+\]`))
+	})
+
+	It("marks opaque tokens instead of failing", func() {
+		session := runCommand("decode-token", "bc4885d950854fed9a938e96b13ca519")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(`{"opaque": true}`))
+	})
+
+	It("does not decode tokens with --format opaque", func() {
+		session := runCommand("decode-token", "--format", "opaque")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(`{"opaque": true}`))
+	})
+
+	It("fails on opaque tokens with --format jwt", func() {
+		session := runCommand("decode-token", "bc4885d950854fed9a938e96b13ca519", "--format", "jwt")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The token is opaque and cannot be decoded locally. Use introspect-token to ask the UAA about it."))
+	})
+
+	It("rejects unknown formats", func() {
+		session := runCommand("decode-token", "--format", "saml")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say(`The token format "saml" is unknown.`))
+	})
+
+	It("requires a context when no token is given", func() {
+		config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+		session := runCommand("decode-token")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("You must have a token in your context to perform this command."))
+	})
+
+	Describe("context --decode", func() {
+		It("shows the decoded tokens alongside the context", func() {
+			session := runCommand("context", "--decode")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say(`"client_id": "cf"`))
+			Expect(session.Out).To(Say(`"decoded_access_token": {`))
+			Expect(session.Out).To(Say(`"expires_at": "2017-07-14T02:40:00Z"`))
+			Expect(session.Out).To(Say(`"decoded_id_token": {`))
+		})
+
+		It("marks opaque tokens instead of failing", func() {
+			c := uaa.NewConfigWithServerURL(server.URL())
+			ctx := uaa.NewContextWithToken("bc4885d950854fed9a938e96b13ca519")
+			ctx.ClientId = "cf"
+			c.AddContext(ctx)
+			config.WriteConfig(c)
+
+			session := runCommand("context", "--decode")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say(`"decoded_access_token": {\s+"opaque": true\s+}`))
+			Expect(session.Out).NotTo(Say("decoded_id_token"))
+		})
+	})
+})
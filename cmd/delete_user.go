@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+func DeleteUserCmd(um uaa.UserManager, log cli.Logger, username, origin string) error {
+	user, err := um.GetByUsername(username, origin, "id,userName,origin")
+	if err != nil {
+		return err
+	}
+
+	_, err = um.Delete(user.ID)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Successfully deleted user %v.", utils.Emphasize(user.Username))
+	return nil
+}
+
+func describeUser(user uaa.ScimUser) string {
+	return fmt.Sprintf("%v (%v)", user.Username, user.Origin)
+}
+
+// DeleteUsersCmd deletes every user matching the SCIM filter. Unless force is
+// set, the matching users are listed and the deletion has to be confirmed.
+func DeleteUsersCmd(um uaa.UserManager, log cli.Logger, filter string, force bool, concurrency int) error {
+	users := []uaa.ScimUser{}
+	matches := um.ListAll(uaa.ListOptions{Filter: filter, Attributes: "id,userName,origin"})
+	for matches.Next() {
+		users = append(users, matches.User())
+	}
+	if matches.Err() != nil {
+		return matches.Err()
+	}
+
+	if len(users) == 0 {
+		log.Info("No users match the filter.")
+		return nil
+	}
+
+	log.Infof("%v users match the filter:", len(users))
+	for _, user := range users {
+		log.Info("  " + describeUser(user))
+	}
+
+	if !force {
+		answer, err := cli.InteractivePrompt{Prompt: fmt.Sprintf(`Delete these %v users? Type "yes" to confirm`, len(users))}.Get()
+		if err != nil || answer != "yes" {
+			log.Info("No users were deleted.")
+			return nil
+		}
+	}
+
+	errs := make([]error, len(users))
+	forEachConcurrently(len(users), concurrency, func(index int) {
+		_, errs[index] = um.Delete(users[index].ID)
+	})
+
+	failed := 0
+	for index, user := range users {
+		if errs[index] != nil {
+			failed++
+			log.Errorf("Could not delete %v: %v", describeUser(user), describeError(errs[index]))
+		} else {
+			log.Infof("Deleted %v", describeUser(user))
+		}
+	}
+
+	log.Infof("Deleted %v of %v users.", len(users)-failed, len(users))
+	if failed > 0 {
+		return fmt.Errorf("%v users could not be deleted.", failed)
+	}
+	return nil
+}
+
+func DeleteUserValidations(cfg uaa.Config, args []string, filter, origin string, concurrency int) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 && filter == "" {
+		return errors.New("The positional argument USERNAME or the --filter option must be specified.")
+	}
+	if len(args) > 0 && filter != "" {
+		return errors.New("Give either USERNAME or --filter, not both.")
+	}
+	if filter != "" && origin != "" {
+		return errors.New("The --origin option cannot be combined with --filter. Add the origin to the filter instead.")
+	}
+	return validateConcurrency(concurrency)
+}
+
+var deleteUserCmd = &cobra.Command{
+	Use:   "delete-user [USERNAME]",
+	Short: "Delete a user, or all users matching a filter",
+	Long:  help.DeleteUser(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(DeleteUserValidations(GetSavedConfig(), args, filter, origin, concurrency), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		um := uaa.UserManager{GetHttpClient(), cfg}
+		var err error
+		if filter != "" {
+			err = DeleteUsersCmd(um, log, filter, force, concurrency)
+		} else {
+			err = DeleteUserCmd(um, log, args[0], origin)
+		}
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(deleteUserCmd)
+	deleteUserCmd.Annotations = make(map[string]string)
+	deleteUserCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	deleteUserCmd.Flags().StringVarP(&origin, "origin", "o", "", `The identity provider in which to search. Examples: uaa, ldap, etc. `)
+	deleteUserCmd.Flags().StringVarP(&filter, "filter", "", "", `delete all users matching this SCIM filter, e.g. 'origin eq "ldap" and active eq false'`)
+	deleteUserCmd.Flags().BoolVarP(&force, "force", "f", false, "delete the users matching --filter without asking for confirmation")
+	deleteUserCmd.Flags().IntVarP(&concurrency, "concurrency", "", 4, "number of users to delete at the same time")
+	deleteUserCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain from which to delete the users")
+}
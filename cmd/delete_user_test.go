@@ -0,0 +1,132 @@
+package cmd_test
+
+import (
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("DeleteUser", func() {
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("delete-user", "woodstock")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a username or a filter", func() {
+			session := runCommand("delete-user")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument USERNAME or the --filter option must be specified."))
+		})
+
+		It("does not take both a username and a filter", func() {
+			session := runCommand("delete-user", "woodstock", "--filter", `origin eq "ldap"`)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Give either USERNAME or --filter, not both."))
+		})
+
+		It("does not combine --origin with --filter", func() {
+			session := runCommand("delete-user", "--filter", `active eq false`, "--origin", "ldap")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The --origin option cannot be combined with --filter."))
+		})
+	})
+
+	It("deletes a user by username", func() {
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock%22+and+origin+eq+%22ldap%22&attributes=id%2CuserName%2Corigin"),
+			RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock", ID: "abcdef", Origin: "ldap"})),
+		))
+		server.RouteToHandler("DELETE", "/Users/abcdef", CombineHandlers(
+			VerifyHeaderKV("Authorization", "bearer access_token"),
+			RespondWith(http.StatusOK, `{"id": "abcdef", "userName": "woodstock"}`),
+		))
+
+		session := runCommand("delete-user", "woodstock", "--origin", "ldap")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("Successfully deleted user woodstock."))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	Describe("with --filter", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/Users", CombineHandlers(
+				VerifyRequest("GET", "/Users", "filter=origin+eq+%22ldap%22&attributes=id%2CuserName%2Corigin&startIndex=1"),
+				RespondWith(http.StatusOK, fixtures.PaginatedResponse(
+					uaa.ScimUser{Username: "woodstock", ID: "woodstock-id", Origin: "ldap"},
+					uaa.ScimUser{Username: "snoopy", ID: "snoopy-id", Origin: "ldap"},
+				)),
+			))
+		})
+
+		It("deletes the matching users once confirmed", func() {
+			server.RouteToHandler("DELETE", "/Users/woodstock-id", RespondWith(http.StatusOK, `{}`))
+			server.RouteToHandler("DELETE", "/Users/snoopy-id", RespondWith(http.StatusOK, `{}`))
+
+			session := runCommandWithStdin(strings.NewReader("yes\n"), "delete-user", "--filter", `origin eq "ldap"`)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say(`2 users match the filter:`))
+			Expect(session.Out).To(Say(`woodstock \(ldap\)`))
+			Expect(session.Out).To(Say(`snoopy \(ldap\)`))
+			Expect(session.Out).To(Say(`Delete these 2 users\? Type "yes" to confirm`))
+			Expect(session.Out).To(Say(`Deleted woodstock \(ldap\)`))
+			Expect(session.Out).To(Say(`Deleted snoopy \(ldap\)`))
+			Expect(session.Out).To(Say("Deleted 2 of 2 users."))
+			Expect(server.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("deletes nothing without confirmation", func() {
+			session := runCommandWithStdin(strings.NewReader("no\n"), "delete-user", "--filter", `origin eq "ldap"`)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("No users were deleted."))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("does not ask with --force and reports failures per user", func() {
+			server.RouteToHandler("DELETE", "/Users/woodstock-id", RespondWith(http.StatusOK, `{}`))
+			server.RouteToHandler("DELETE", "/Users/snoopy-id", RespondWith(http.StatusNotFound, `{"error": "scim_resource_not_found", "error_description": "User snoopy-id does not exist"}`))
+
+			session := runCommand("delete-user", "--filter", `origin eq "ldap"`, "--force", "--concurrency", "1")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Out).NotTo(Say("Type \"yes\" to confirm"))
+			Expect(session.Err).To(Say(`Could not delete snoopy \(ldap\): User snoopy-id does not exist`))
+			Expect(session.Err).To(Say("1 users could not be deleted."))
+			Expect(session.Out).To(Say("Deleted 1 of 2 users."))
+		})
+	})
+
+	It("reports when no users match the filter", func() {
+		server.RouteToHandler("GET", "/Users", RespondWith(http.StatusOK, fixtures.PaginatedResponse()))
+
+		session := runCommand("delete-user", "--filter", `origin eq "ldap"`)
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("No users match the filter."))
+	})
+})
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"errors"
+	"github.com/spf13/cobra"
+	"net/http"
+)
+
+func DiscoveryCmd(cfg uaa.Config, httpClient *http.Client, printer cli.Printer) error {
+	discovery, err := uaa.Discover(httpClient, cfg)
+	if err != nil {
+		return errors.New("The OpenID Connect discovery document of the target could not be fetched.")
+	}
+
+	target := cfg.GetActiveTarget()
+	target.Discovery = &discovery
+	cfg.AddTarget(target)
+	config.WriteConfig(cfg)
+
+	return printer.Print(discovery)
+}
+
+var discoveryCmd = &cobra.Command{
+	Use:   "discovery",
+	Short: "Show the OpenID Connect discovery document of the target",
+	Long:  help.Discovery(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(EnsureTargetInConfig(cfg), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyErrorsWithRetry(DiscoveryCmd(cfg, GetHttpClient(), cli.NewJsonPrinter(log)), cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(discoveryCmd)
+	discoveryCmd.Annotations = make(map[string]string)
+	discoveryCmd.Annotations[INTRO_CATEGORY] = "true"
+}
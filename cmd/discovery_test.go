@@ -0,0 +1,86 @@
+package cmd_test
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"net/http"
+)
+
+const DiscoveryResponseJson string = `{
+  "issuer": "https://login.example.com/oauth/token",
+  "authorization_endpoint": "https://login.example.com/oauth/authorize",
+  "token_endpoint": "https://login.example.com/oauth/token",
+  "userinfo_endpoint": "https://login.example.com/userinfo",
+  "jwks_uri": "https://login.example.com/token_keys",
+  "end_session_endpoint": "https://login.example.com/logout.do",
+  "scopes_supported": ["openid", "profile", "email"],
+  "response_types_supported": ["code", "code id_token", "id_token", "token id_token"],
+  "subject_types_supported": ["public"],
+  "id_token_signing_alg_values_supported": ["RS256", "HS256"],
+  "token_endpoint_auth_methods_supported": ["client_secret_basic", "client_secret_post"],
+  "claims_supported": ["sub", "user_name", "origin", "iss", "auth_time", "amr", "acr", "client_id", "aud", "zid", "grant_type", "user_id", "azp", "scope", "exp", "iat", "jti", "rev_sig", "cid", "given_name", "family_name", "phone_number", "email"],
+  "service_documentation": "http://docs.cloudfoundry.org/api/uaa/",
+  "ui_locales_supported": ["en-US"]
+}`
+
+var _ = Describe("Discovery", func() {
+	BeforeEach(func() {
+		config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+	})
+
+	It("prints the discovery document and saves it with the target", func() {
+		server.RouteToHandler("GET", "/.well-known/openid-configuration", CombineHandlers(
+			VerifyRequest("GET", "/.well-known/openid-configuration"),
+			RespondWith(http.StatusOK, DiscoveryResponseJson),
+		))
+
+		session := runCommand("discovery")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(DiscoveryResponseJson))
+		Expect(config.ReadConfig().GetActiveTarget().AuthorizationEndpoint()).To(Equal("https://login.example.com/oauth/authorize"))
+	})
+
+	It("reports when the document cannot be fetched", func() {
+		server.RouteToHandler("GET", "/.well-known/openid-configuration", RespondWith(http.StatusNotFound, ""))
+
+		session := runCommand("discovery")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The OpenID Connect discovery document of the target could not be fetched."))
+	})
+
+	It("sends token requests to the discovered token endpoint", func() {
+		loginServer := NewServer()
+		defer loginServer.Close()
+		loginServer.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+			VerifyFormKV("grant_type", "client_credentials"),
+			RespondWith(http.StatusOK, `{"access_token": "bc4885d950854fed9a938e96b13ca519", "token_type": "bearer"}`),
+		))
+		c := uaa.NewConfigWithServerURL(server.URL())
+		target := c.GetActiveTarget()
+		target.Discovery = &uaa.OpenIdConfiguration{TokenEndpoint: loginServer.URL() + "/oauth/token"}
+		c.AddTarget(target)
+		config.WriteConfig(c)
+
+		session := runCommand("get-client-credentials-token", "admin", "-s", "adminsecret")
+
+		Eventually(session).Should(Exit(0))
+		Expect(loginServer.ReceivedRequests()).To(HaveLen(1))
+		Expect(server.ReceivedRequests()).To(HaveLen(0))
+	})
+
+	It("requires a target", func() {
+		config.WriteConfig(uaa.NewConfig())
+
+		session := runCommand("discovery")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("You must set a target in order to use this command."))
+	})
+})
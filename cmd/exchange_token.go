@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"net/http"
+	"strings"
+)
+
+// Token exchange flags
+var (
+	subjectToken       string
+	subjectTokenType   string
+	actorToken         string
+	actorTokenType     string
+	requestedTokenType string
+	audience           string
+	exchangeScope      string
+	saveToken          bool
+)
+
+func availableTokenTypes() []string {
+	return []string{"access_token", "refresh_token", "id_token", "jwt"}
+}
+
+func parseTokenType(tokenType string) (uaa.TokenType, error) {
+	if tokenType == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(tokenType, "urn:") {
+		return uaa.TokenType(tokenType), nil
+	}
+	if !utils.Contains(availableTokenTypes(), tokenType) {
+		return "", fmt.Errorf(`The token type "%v" is unknown. Available token types: %v`, tokenType, utils.StringSliceStringifier(availableTokenTypes()))
+	}
+	return uaa.TokenType("urn:ietf:params:oauth:token-type:" + tokenType), nil
+}
+
+func ExchangeTokenValidations(cfg uaa.Config, args []string, clientSecret, subjectToken string) error {
+	if err := EnsureTargetInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) < 1 {
+		return MissingArgumentError("client_id")
+	}
+	if err := validateClientAuthentication(clientSecret); err != nil {
+		return err
+	}
+	if subjectToken == "" && cfg.GetActiveContext().AccessToken == "" {
+		return MissingArgumentWithExplanationError("subject_token", "There is no access_token in the active context to use as the subject token.")
+	}
+	for _, tokenType := range []string{subjectTokenType, actorTokenType, requestedTokenType} {
+		if _, err := parseTokenType(tokenType); err != nil {
+			return err
+		}
+	}
+	return validateTokenFormatError(tokenFormat)
+}
+
+func ExchangeTokenCmd(cfg uaa.Config, httpClient *http.Client, log cli.Logger, printer cli.Printer, clientId, clientSecret, tokenFormat string, save bool) error {
+	activeContext := cfg.GetActiveContext()
+
+	request := uaa.TokenExchangeRequest{
+		SubjectToken: subjectToken,
+		ActorToken:   actorToken,
+		Audience:     audience,
+		Scope:        requestedScope(exchangeScope),
+	}
+	if request.SubjectToken == "" {
+		request.SubjectToken = activeContext.AccessToken
+	}
+	request.SubjectTokenType, _ = parseTokenType(subjectTokenType)
+	request.ActorTokenType, _ = parseTokenType(actorTokenType)
+	request.RequestedTokenType, _ = parseTokenType(requestedTokenType)
+
+	clientAuth, err := buildClientAuthentication(clientId, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	exchangeClient := uaa.TokenExchangeClient{ClientId: clientId, ClientSecret: clientSecret, ClientAuth: clientAuth}
+	tokenResponse, err := exchangeClient.RequestToken(httpClient, cfg, uaa.TokenFormat(tokenFormat), request)
+	if err != nil {
+		return errors.New("An error occurred while exchanging token.")
+	}
+
+	if !save {
+		return printer.Print(tokenResponse)
+	}
+
+	// The active context only names the user when its token was the subject.
+	username := tokenUsername(tokenResponse.AccessToken)
+	if username == "" && subjectToken == "" {
+		username = activeContext.Username
+	}
+	ctx := uaa.UaaContext{
+		GrantType:     uaa.TOKEN_EXCHANGE,
+		ClientId:      clientId,
+		Username:      username,
+		TokenResponse: tokenResponse,
+	}
+	cfg.AddContext(ctx)
+	config.WriteConfig(cfg)
+	log.Info("Exchanged token successfully fetched and added to context.")
+	warnOnScopeMismatch(log, request.Scope, tokenResponse.Scope)
+	return nil
+}
+
+var exchangeTokenCmd = &cobra.Command{
+	Use:   "exchange-token CLIENT_ID -s CLIENT_SECRET",
+	Short: "Exchange a token for another token using the token-exchange grant type",
+	Long:  help.TokenExchange(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(ExchangeTokenValidations(cfg, args, clientSecret, subjectToken), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		err := ExchangeTokenCmd(cfg, GetHttpClient(), log, cli.NewJsonPrinter(log), args[0], clientSecret, tokenFormat, saveToken)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(exchangeTokenCmd)
+	exchangeTokenCmd.Annotations = make(map[string]string)
+	exchangeTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
+
+	tokenTypes := utils.StringSliceStringifier(availableTokenTypes())
+	exchangeTokenCmd.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "client secret")
+	addClientAuthenticationFlags(exchangeTokenCmd, uaa.CLIENT_SECRET_POST)
+	exchangeTokenCmd.Flags().StringVarP(&subjectToken, "subject-token", "", "", "token to exchange (defaults to the access_token of the active context)")
+	exchangeTokenCmd.Flags().StringVarP(&subjectTokenType, "subject-token-type", "", "", "type of the subject token, one of "+tokenTypes+" (default access_token)")
+	exchangeTokenCmd.Flags().StringVarP(&actorToken, "actor-token", "", "", "token representing the party acting on behalf of the subject")
+	exchangeTokenCmd.Flags().StringVarP(&actorTokenType, "actor-token-type", "", "", "type of the actor token, one of "+tokenTypes+" (default access_token)")
+	exchangeTokenCmd.Flags().StringVarP(&requestedTokenType, "requested-token-type", "", "", "type of token to request, one of "+tokenTypes)
+	exchangeTokenCmd.Flags().StringVarP(&audience, "audience", "", "", "logical name of the service where the token will be used")
+	exchangeTokenCmd.Flags().StringVarP(&exchangeScope, "scope", "", "", "comma or space separated scopes to request in the exchanged token")
+	exchangeTokenCmd.Flags().BoolVarP(&saveToken, "save", "", false, "store the exchanged token in a new context instead of printing it")
+	exchangeTokenCmd.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
+}
@@ -0,0 +1,147 @@
+package cmd_test
+
+import (
+	"encoding/base64"
+
+	"code.cloudfoundry.org/uaa-cli/config"
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"net/http"
+)
+
+var _ = Describe("ExchangeToken", func() {
+	var exchangedTokenResponseJson = `{
+	  "access_token" : "exchanged-token",
+	  "issued_token_type" : "urn:ietf:params:oauth:token-type:access_token",
+	  "token_type" : "bearer",
+	  "expires_in" : 600,
+	  "scope" : "orders.read",
+	  "jti" : "exchanged-jti"
+	}`
+
+	BeforeEach(func() {
+		c := NewConfigWithServerURL(server.URL())
+		ctx := NewContextWithToken("subject-access-token")
+		ctx.ClientId = "cf"
+		ctx.Username = "woodstock"
+		ctx.GrantType = PASSWORD
+		c.AddContext(ctx)
+		config.WriteConfig(c)
+	})
+
+	It("exchanges the active context's token and prints the result", func() {
+		server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+			RespondWith(http.StatusOK, exchangedTokenResponseJson),
+			VerifyFormKV("client_id", "gateway"),
+			VerifyFormKV("client_secret", "gatewaysecret"),
+			VerifyFormKV("grant_type", "urn:ietf:params:oauth:grant-type:token-exchange"),
+			VerifyFormKV("subject_token", "subject-access-token"),
+			VerifyFormKV("subject_token_type", "urn:ietf:params:oauth:token-type:access_token"),
+			VerifyFormKV("audience", "orders-service"),
+			VerifyFormKV("scope", "orders.read"),
+		))
+
+		session := runCommand("exchange-token", "gateway", "-s", "gatewaysecret", "--audience", "orders-service", "--scope", "orders.read")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(`{
+		  "access_token" : "exchanged-token",
+		  "refresh_token" : "",
+		  "id_token" : "",
+		  "issued_token_type" : "urn:ietf:params:oauth:token-type:access_token",
+		  "token_type" : "bearer",
+		  "expires_in" : 600,
+		  "scope" : "orders.read",
+		  "jti" : "exchanged-jti"
+		}`))
+		Expect(config.ReadConfig().GetActiveContext().AccessToken).To(Equal("subject-access-token"))
+	})
+
+	It("exchanges an explicit subject and actor token and saves the result", func() {
+		server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+			RespondWith(http.StatusOK, exchangedTokenResponseJson),
+			VerifyFormKV("subject_token", "some-id-token"),
+			VerifyFormKV("subject_token_type", "urn:ietf:params:oauth:token-type:id_token"),
+			VerifyFormKV("actor_token", "actor-jwt"),
+			VerifyFormKV("actor_token_type", "urn:ietf:params:oauth:token-type:jwt"),
+			VerifyFormKV("requested_token_type", "urn:ietf:params:oauth:token-type:access_token"),
+		))
+
+		session := runCommand("exchange-token", "gateway", "-s", "gatewaysecret",
+			"--subject-token", "some-id-token",
+			"--subject-token-type", "id_token",
+			"--actor-token", "actor-jwt",
+			"--actor-token-type", "urn:ietf:params:oauth:token-type:jwt",
+			"--requested-token-type", "access_token",
+			"--save")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("Exchanged token successfully fetched and added to context."))
+		Expect(config.ReadConfig().GetActiveContext().AccessToken).To(Equal("exchanged-token"))
+		Expect(config.ReadConfig().GetActiveContext().ClientId).To(Equal("gateway"))
+		Expect(config.ReadConfig().GetActiveContext().Username).To(BeEmpty())
+		Expect(config.ReadConfig().GetActiveContext().GrantType).To(Equal(TOKEN_EXCHANGE))
+	})
+
+	It("keeps the username of the active context when its token is the subject", func() {
+		server.RouteToHandler("POST", "/oauth/token", RespondWith(http.StatusOK, exchangedTokenResponseJson))
+
+		session := runCommand("exchange-token", "gateway", "-s", "gatewaysecret", "--save")
+
+		Eventually(session).Should(Exit(0))
+		Expect(config.ReadConfig().GetActiveContext().Username).To(Equal("woodstock"))
+	})
+
+	It("takes the username from the exchanged token", func() {
+		encode := func(segment string) string {
+			return base64.RawURLEncoding.EncodeToString([]byte(segment))
+		}
+		exchangedJwt := encode(`{"alg":"RS256"}`) + "." + encode(`{"user_name":"snoopy","client_id":"gateway"}`) + "." + encode("signature")
+		server.RouteToHandler("POST", "/oauth/token", RespondWith(http.StatusOK, `{"access_token": "`+exchangedJwt+`", "token_type": "bearer"}`))
+
+		session := runCommand("exchange-token", "gateway", "-s", "gatewaysecret", "--subject-token", "snoopys-token", "--save")
+
+		Eventually(session).Should(Exit(0))
+		Expect(config.ReadConfig().GetActiveContext().Username).To(Equal("snoopy"))
+	})
+
+	It("displays an error when the exchange fails", func() {
+		server.RouteToHandler("POST", "/oauth/token",
+			RespondWith(http.StatusBadRequest, `{"error":"invalid_target"}`),
+		)
+
+		session := runCommand("exchange-token", "gateway", "-s", "gatewaysecret")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("An error occurred while exchanging token."))
+	})
+
+	Describe("Validations", func() {
+		It("requires a client secret", func() {
+			session := runCommand("exchange-token", "gateway")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `client_secret` must be specified."))
+		})
+
+		It("rejects unknown token types", func() {
+			session := runCommand("exchange-token", "gateway", "-s", "gatewaysecret", "--requested-token-type", "bogus")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`The token type "bogus" is unknown.`))
+		})
+
+		It("requires a subject token when there is no active context", func() {
+			config.WriteConfig(NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("exchange-token", "gateway", "-s", "gatewaysecret")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `subject_token` must be specified."))
+		})
+	})
+})
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+func ExpirePasswordCmd(um uaa.UserManager, log cli.Logger, username, origin string) error {
+	user, err := um.GetByUsername(username, origin, "id,userName")
+	if err != nil {
+		return err
+	}
+
+	if err := um.ExpirePassword(user.ID); err != nil {
+		return errors.New(describeError(err))
+	}
+
+	log.Infof("The password of user %v has expired. It must be changed at the next login.", utils.Emphasize(user.Username))
+	return nil
+}
+
+func ExpirePasswordValidations(cfg uaa.Config, args []string) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("The positional argument USERNAME must be specified.")
+	}
+	return nil
+}
+
+var expirePasswordCmd = &cobra.Command{
+	Use:   "expire-password USERNAME",
+	Short: "Make a user change their password at the next login",
+	Long:  help.ExpirePassword(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(ExpirePasswordValidations(GetSavedConfig(), args), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		um := uaa.UserManager{GetHttpClient(), cfg}
+		err := ExpirePasswordCmd(um, log, args[0], origin)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(expirePasswordCmd)
+	expirePasswordCmd.Annotations = make(map[string]string)
+	expirePasswordCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	expirePasswordCmd.Flags().StringVarP(&origin, "origin", "o", "", `The identity provider in which to search. Examples: uaa, ldap, etc. `)
+	expirePasswordCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain of the user")
+}
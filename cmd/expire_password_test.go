@@ -0,0 +1,68 @@
+package cmd_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ExpirePassword", func() {
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("expire-password", "woodstock")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a username", func() {
+			session := runCommand("expire-password")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument USERNAME must be specified."))
+		})
+	})
+
+	It("requires the user to change their password", func() {
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock%22+and+origin+eq+%22uaa%22&attributes=id%2CuserName"),
+			RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock", ID: "abcdef"})),
+		))
+		server.RouteToHandler("PATCH", "/Users/abcdef/status", CombineHandlers(
+			VerifyHeaderKV("Authorization", "bearer access_token"),
+			VerifyJSON(`{"passwordChangeRequired": true}`),
+			RespondWith(http.StatusOK, `{"passwordChangeRequired": true}`),
+		))
+
+		session := runCommand("expire-password", "woodstock", "--origin", "uaa")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("The password of user woodstock has expired. It must be changed at the next login."))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("reports users which cannot be found", func() {
+		server.RouteToHandler("GET", "/Users", RespondWith(http.StatusOK, fixtures.PaginatedResponse()))
+
+		session := runCommand("expire-password", "woodstock")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("User woodstock not found"))
+	})
+})
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+// Export flags
+var (
+	exportFormat   string
+	exportPageSize int
+)
+
+func availableExportFormats() []string {
+	return []string{"jsonl", "csv", "scim"}
+}
+
+// userWriter streams exported users in one of the export formats.
+type userWriter interface {
+	Begin() error
+	Write(user uaa.ScimUser) error
+	End() error
+}
+
+func newUserWriter(out io.Writer, format string) userWriter {
+	switch format {
+	case "csv":
+		return &csvUserWriter{writer: csv.NewWriter(out)}
+	case "scim":
+		return &scimBulkUserWriter{out: out}
+	default:
+		return &jsonlUserWriter{encoder: json.NewEncoder(out)}
+	}
+}
+
+type jsonlUserWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonlUserWriter) Begin() error {
+	return nil
+}
+
+func (w *jsonlUserWriter) Write(user uaa.ScimUser) error {
+	return w.encoder.Encode(user)
+}
+
+func (w *jsonlUserWriter) End() error {
+	return nil
+}
+
+// csvUserWriter writes the columns read by import-users, so that an export
+// can be imported into another zone.
+type csvUserWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvUserWriter) Begin() error {
+	return w.writer.Write(importColumns)
+}
+
+func (w *csvUserWriter) Write(user uaa.ScimUser) error {
+	return w.writer.Write(exportedUser(user).csvRecord())
+}
+
+func (w *csvUserWriter) End() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func exportedUser(user uaa.ScimUser) ImportedUser {
+	exported := ImportedUser{Username: user.Username, Origin: user.Origin}
+	if user.Name != nil {
+		exported.GivenName = user.Name.GivenName
+		exported.FamilyName = user.Name.FamilyName
+	}
+	for _, email := range user.Emails {
+		exported.Emails = append(exported.Emails, email.Value)
+	}
+	for _, phone := range user.PhoneNumbers {
+		exported.Phones = append(exported.Phones, phone.Value)
+	}
+	for _, group := range user.Groups {
+		if group.Type == "DIRECT" {
+			exported.Groups = append(exported.Groups, group.Display)
+		}
+	}
+	return exported
+}
+
+type scimBulkOperation struct {
+	Method string       `json:"method"`
+	Path   string       `json:"path"`
+	BulkId string       `json:"bulkId"`
+	Data   uaa.ScimUser `json:"data"`
+}
+
+// scimBulkUserWriter writes a SCIM bulk request creating every exported
+// user. Attributes which the UAA assigns itself are left out of the data.
+type scimBulkUserWriter struct {
+	out   io.Writer
+	count int
+}
+
+func (w *scimBulkUserWriter) Begin() error {
+	_, err := io.WriteString(w.out, `{"schemas":["urn:ietf:params:scim:api:messages:2.0:BulkRequest"],"Operations":[`)
+	return err
+}
+
+func (w *scimBulkUserWriter) Write(user uaa.ScimUser) error {
+	w.count++
+	operation := scimBulkOperation{Method: "POST", Path: "/Users", BulkId: user.ID, Data: user}
+	if operation.BulkId == "" {
+		operation.BulkId = fmt.Sprintf("user-%v", w.count)
+	}
+	operation.Data.ID = ""
+	operation.Data.Meta = nil
+	operation.Data.ZoneId = ""
+	operation.Data.Groups = nil
+	operation.Data.Approvals = nil
+	operation.Data.PasswordLastModified = ""
+	operation.Data.PreviousLogonTime = 0
+	operation.Data.LastLogonTime = 0
+
+	bytes, err := json.Marshal(operation)
+	if err != nil {
+		return err
+	}
+	if w.count > 1 {
+		bytes = append([]byte(","), bytes...)
+	}
+	_, err = w.out.Write(bytes)
+	return err
+}
+
+func (w *scimBulkUserWriter) End() error {
+	_, err := io.WriteString(w.out, "]}\n")
+	return err
+}
+
+func ExportUsersCmd(um uaa.UserManager, out, progress io.Writer, format, filter, attributes string, pageSize int) error {
+	writer := newUserWriter(out, format)
+	if err := writer.Begin(); err != nil {
+		return err
+	}
+
+	exported := 0
+	users := um.ListAll(uaa.ListOptions{Filter: filter, Attributes: attributes, PageSize: pageSize})
+	for users.Next() {
+		if err := writer.Write(users.User()); err != nil {
+			return err
+		}
+		exported++
+		if exported%pageSize == 0 || exported == users.TotalResults() {
+			fmt.Fprintf(progress, "Exported %v of %v users\n", exported, users.TotalResults())
+		}
+	}
+	if users.Err() != nil {
+		return users.Err()
+	}
+
+	return writer.End()
+}
+
+func ExportUsersValidations(cfg uaa.Config, format string, pageSize int) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if !utils.Contains(availableExportFormats(), format) {
+		return fmt.Errorf(`The export format "%v" is unknown. Available formats: %v`, format, utils.StringSliceStringifier(availableExportFormats()))
+	}
+	if pageSize < 1 {
+		return errors.New("The page size must be at least 1.")
+	}
+	return nil
+}
+
+var exportUsersCmd = &cobra.Command{
+	Use:   "export-users",
+	Short: "Export all users, page by page",
+	Long:  help.ExportUsers(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(ExportUsersValidations(GetSavedConfig(), exportFormat, exportPageSize), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		um := uaa.UserManager{GetHttpClient(), cfg}
+		err := ExportUsersCmd(um, os.Stdout, os.Stderr, exportFormat, filter, attributes, exportPageSize)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(exportUsersCmd)
+	exportUsersCmd.Annotations = make(map[string]string)
+	exportUsersCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	exportUsersCmd.Flags().StringVarP(&exportFormat, "format", "", "jsonl", fmt.Sprintf("output format, one of %v", utils.StringSliceStringifier(availableExportFormats())))
+	exportUsersCmd.Flags().StringVarP(&filter, "filter", "", "", `a SCIM filter selecting the users to export, e.g. 'origin eq "ldap"'`)
+	exportUsersCmd.Flags().StringVarP(&attributes, "attributes", "a", "", `include only these comma-separated user attributes`)
+	exportUsersCmd.Flags().IntVarP(&exportPageSize, "page-size", "", 500, "number of users to fetch with each request")
+	exportUsersCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain from which to export the users")
+}
@@ -0,0 +1,137 @@
+package cmd_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ExportUsers", func() {
+	page := func(startIndex, totalResults int, usernames ...string) string {
+		resources := ""
+		for i, username := range usernames {
+			if i > 0 {
+				resources += ","
+			}
+			resources += fmt.Sprintf(`{"id": "%v-id", "userName": "%v", "origin": "uaa", "meta": {"version": 1},
+				"name": {"givenName": "G", "familyName": "F"}, "emails": [{"value": "%v@example.com", "primary": true}],
+				"groups": [{"value": "g1", "display": "uaa.admin", "type": "DIRECT"}, {"value": "g2", "display": "openid", "type": "INDIRECT"}]}`,
+				username, username, username)
+		}
+		return fmt.Sprintf(`{"resources": [%v], "startIndex": %v, "itemsPerPage": 2, "totalResults": %v, "schemas": ["urn:scim:schemas:core:1.0"]}`,
+			resources, startIndex, totalResults)
+	}
+
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("export-users")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("rejects unknown formats", func() {
+			session := runCommand("export-users", "--format", "xml")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`The export format "xml" is unknown. Available formats: \[jsonl, csv, scim\]`))
+		})
+
+		It("requires a positive page size", func() {
+			session := runCommand("export-users", "--page-size", "0")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The page size must be at least 1."))
+		})
+	})
+
+	Describe("paging", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest("GET", "/Users", "count=2&filter=origin+eq+%22uaa%22&startIndex=1"),
+					VerifyHeaderKV("Authorization", "bearer access_token"),
+					RespondWith(http.StatusOK, page(1, 3, "woodstock", "snoopy")),
+				),
+				CombineHandlers(
+					VerifyRequest("GET", "/Users", "count=2&filter=origin+eq+%22uaa%22&startIndex=3"),
+					RespondWith(http.StatusOK, page(3, 3, "linus")),
+				),
+			)
+		})
+
+		It("walks all pages and writes JSON lines", func() {
+			session := runCommand("export-users", "--filter", `origin eq "uaa"`, "--page-size", "2")
+
+			Eventually(session).Should(Exit(0))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+			Expect(session.Out).To(Say(`{"id":"woodstock-id",.*"userName":"woodstock"`))
+			Expect(session.Out).To(Say(`{"id":"snoopy-id",.*"userName":"snoopy"`))
+			Expect(session.Out).To(Say(`{"id":"linus-id",.*"userName":"linus"`))
+			Expect(session.Err).To(Say("Exported 2 of 3 users"))
+			Expect(session.Err).To(Say("Exported 3 of 3 users"))
+		})
+
+		It("writes CSV which import-users can read", func() {
+			session := runCommand("export-users", "--filter", `origin eq "uaa"`, "--page-size", "2", "--format", "csv")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal(
+				"username,givenName,familyName,emails,phones,origin,password,groups,status,error\n" +
+					"woodstock,G,F,woodstock@example.com,,uaa,,uaa.admin,,\n" +
+					"snoopy,G,F,snoopy@example.com,,uaa,,uaa.admin,,\n" +
+					"linus,G,F,linus@example.com,,uaa,,uaa.admin,,\n"))
+		})
+
+		It("writes a SCIM bulk request", func() {
+			session := runCommand("export-users", "--filter", `origin eq "uaa"`, "--page-size", "2", "--format", "scim")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out.Contents()).To(MatchJSON(`{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:BulkRequest"],
+				"Operations": [
+					{"method": "POST", "path": "/Users", "bulkId": "woodstock-id", "data": {"userName": "woodstock", "origin": "uaa", "name": {"givenName": "G", "familyName": "F"}, "emails": [{"value": "woodstock@example.com", "primary": true}]}},
+					{"method": "POST", "path": "/Users", "bulkId": "snoopy-id", "data": {"userName": "snoopy", "origin": "uaa", "name": {"givenName": "G", "familyName": "F"}, "emails": [{"value": "snoopy@example.com", "primary": true}]}},
+					{"method": "POST", "path": "/Users", "bulkId": "linus-id", "data": {"userName": "linus", "origin": "uaa", "name": {"givenName": "G", "familyName": "F"}, "emails": [{"value": "linus@example.com", "primary": true}]}}
+				]
+			}`))
+		})
+	})
+
+	It("passes the attribute projection along", func() {
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "attributes=userName&count=500&startIndex=1"),
+			RespondWith(http.StatusOK, `{"resources": [{"userName": "woodstock"}], "totalResults": 1}`),
+		))
+
+		session := runCommand("export-users", "--attributes", "userName")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say(`{"userName":"woodstock"}`))
+	})
+
+	It("stops when the UAA returns an empty page", func() {
+		server.RouteToHandler("GET", "/Users", RespondWith(http.StatusOK, `{"resources": [], "totalResults": 10}`))
+
+		session := runCommand("export-users")
+
+		Eventually(session).Should(Exit(0))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+})
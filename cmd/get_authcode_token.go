@@ -27,8 +27,8 @@ func AuthcodeTokenArgumentValidation(cfg uaa.Config, args []string, clientSecret
 	if port == 0 {
 		return MissingArgumentWithExplanationError("port", `The port number must correspond to a localhost redirect_uri specified in the client configuration.`)
 	}
-	if clientSecret == "" {
-		return MissingArgumentError("client_secret")
+	if err := validateClientAuthentication(clientSecret); err != nil {
+		return err
 	}
 	return validateTokenFormatError(tokenFormat)
 }
@@ -38,6 +38,7 @@ func AuthcodeTokenCommandRun(doneRunning chan bool, clientId string, authcodeImp
 	authcodeImp.Authorize()
 	tokenResponse := <-authcodeImp.Done()
 	addAuthcodeTokenToContext(clientId, tokenResponse, log)
+	warnOnScopeMismatch(*log, requestedScope(scope), tokenResponse.Scope)
 	doneRunning <- true
 }
 
@@ -50,7 +51,11 @@ var getAuthcodeToken = &cobra.Command{
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		done := make(chan bool)
-		authcodeImp := cli.NewAuthcodeClientImpersonator(GetHttpClient(), GetSavedConfig(), args[0], clientSecret, tokenFormat, scope, port, log, open.Run)
+		clientAuth, err := buildClientAuthentication(args[0], clientSecret)
+		NotifyErrorsWithRetry(err, GetSavedConfig(), log)
+		authcodeImp := cli.NewAuthcodeClientImpersonator(GetHttpClient(), GetSavedConfig(), args[0], clientSecret, tokenFormat, requestedScope(scope), port, log, open.Run)
+		authcodeImp.ClientAuth = clientAuth
+		authcodeImp.Origin = origin
 		go AuthcodeTokenCommandRun(done, args[0], authcodeImp, GetLogger())
 		<-done
 	},
@@ -59,7 +64,9 @@ var getAuthcodeToken = &cobra.Command{
 func init() {
 	getAuthcodeToken.Flags().IntVarP(&port, "port", "", 0, "port on which to run local callback server")
 	getAuthcodeToken.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "client secret")
-	getAuthcodeToken.Flags().StringVarP(&scope, "scope", "", "openid", "comma-separated scopes to request in token")
+	addClientAuthenticationFlags(getAuthcodeToken, uaa.CLIENT_SECRET_POST)
+	getAuthcodeToken.Flags().StringVarP(&scope, "scope", "", "openid", "comma or space separated scopes to request in token")
+	getAuthcodeToken.Flags().StringVarP(&origin, "origin", "o", "", "origin of the identity provider which should authenticate the user, sent as login_hint")
 	getAuthcodeToken.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
 	getAuthcodeToken.Annotations = make(map[string]string)
 	getAuthcodeToken.Annotations[TOKEN_CATEGORY] = "true"
@@ -16,14 +16,19 @@ func GetClientCredentialsTokenValidations(cfg uaa.Config, args []string, clientS
 	if len(args) < 1 {
 		return MissingArgumentError("client_id")
 	}
-	if clientSecret == "" {
-		return MissingArgumentError("client_secret")
+	if err := validateClientAuthentication(clientSecret); err != nil {
+		return err
 	}
 	return validateTokenFormatError(tokenFormat)
 }
 
-func GetClientCredentialsTokenCmd(cfg uaa.Config, httpClient *http.Client, clientId, clientSecret string) error {
-	ccClient := uaa.ClientCredentialsClient{ClientId: clientId, ClientSecret: clientSecret}
+func GetClientCredentialsTokenCmd(cfg uaa.Config, httpClient *http.Client, clientId, clientSecret, scope string) error {
+	clientAuth, err := buildClientAuthentication(clientId, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	ccClient := uaa.ClientCredentialsClient{ClientId: clientId, ClientSecret: clientSecret, ClientAuth: clientAuth, Scope: requestedScope(scope)}
 	tokenResponse, err := ccClient.RequestToken(httpClient, cfg, uaa.TokenFormat(tokenFormat))
 	if err != nil {
 		return errors.New("An error occurred while fetching token.")
@@ -37,6 +42,7 @@ func GetClientCredentialsTokenCmd(cfg uaa.Config, httpClient *http.Client, clien
 	cfg.AddContext(activeContext)
 	config.WriteConfig(cfg)
 	log.Info("Access token successfully fetched and added to context.")
+	warnOnScopeMismatch(log, requestedScope(scope), tokenResponse.Scope)
 	return nil
 }
 
@@ -50,13 +56,15 @@ var getClientCredentialsTokenCmd = &cobra.Command{
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := GetSavedConfig()
-		NotifyErrorsWithRetry(GetClientCredentialsTokenCmd(cfg, GetHttpClient(), args[0], clientSecret), cfg, log)
+		NotifyErrorsWithRetry(GetClientCredentialsTokenCmd(cfg, GetHttpClient(), args[0], clientSecret, tokenScope), cfg, log)
 	},
 }
 
 func init() {
 	RootCmd.AddCommand(getClientCredentialsTokenCmd)
 	getClientCredentialsTokenCmd.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "client secret")
+	addClientAuthenticationFlags(getClientCredentialsTokenCmd, uaa.CLIENT_SECRET_POST)
+	addScopeFlag(getClientCredentialsTokenCmd)
 	getClientCredentialsTokenCmd.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
 	getClientCredentialsTokenCmd.Annotations = make(map[string]string)
 	getClientCredentialsTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
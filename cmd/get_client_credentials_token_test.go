@@ -3,12 +3,18 @@ package cmd_test
 import (
 	"code.cloudfoundry.org/uaa-cli/config"
 	. "code.cloudfoundry.org/uaa-cli/uaa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
 	. "github.com/onsi/gomega/gexec"
 	. "github.com/onsi/gomega/ghttp"
+	"io/ioutil"
 	"net/http"
+	"path"
 )
 
 var _ = Describe("GetClientCredentialsToken", func() {
@@ -138,6 +144,93 @@ var _ = Describe("GetClientCredentialsToken", func() {
 				Expect(session).To(Exit(1))
 			})
 		})
+
+		Describe("configuring scopes", func() {
+			It("requests the given scopes", func() {
+				server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+					RespondWith(http.StatusOK, `{"access_token": "bc4885d950854fed9a938e96b13ca519", "token_type": "bearer", "scope": "clients.read scim.write"}`),
+					VerifyFormKV("grant_type", "client_credentials"),
+					VerifyFormKV("scope", "clients.read scim.write"),
+				))
+
+				session := runCommand("get-client-credentials-token", "admin", "-s", "adminsecret", "--scope", "clients.read,scim.write")
+
+				Eventually(session).Should(Exit(0))
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+				Expect(session.Out).NotTo(Say("did not grant"))
+			})
+
+			It("warns when the UAA grants other scopes than requested", func() {
+				server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+					RespondWith(http.StatusOK, `{"access_token": "bc4885d950854fed9a938e96b13ca519", "token_type": "bearer", "scope": "clients.read uaa.none"}`),
+					VerifyFormKV("scope", "clients.read scim.write"),
+				))
+
+				session := runCommand("get-client-credentials-token", "admin", "-s", "adminsecret", "--scope", "clients.read scim.write")
+
+				Eventually(session).Should(Exit(0))
+				Expect(session.Out).To(Say("The UAA did not grant the requested scopes: scim.write"))
+				Expect(session.Out).To(Say("The UAA granted scopes that were not requested: uaa.none"))
+			})
+		})
+
+		Describe("configuring client authentication", func() {
+			It("can send the client secret with HTTP Basic authentication", func() {
+				server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+					RespondWith(http.StatusOK, opaqueTokenResponseJson),
+					VerifyBasicAuth("admin", "adminsecret"),
+					VerifyFormKV("client_id", "admin"),
+					VerifyFormKV("grant_type", "client_credentials"),
+				))
+
+				session := runCommand("get-client-credentials-token", "admin", "-s", "adminsecret", "--auth-method", "client_secret_basic")
+
+				Eventually(session).Should(Exit(0))
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+				Expect(server.ReceivedRequests()[0].Form).NotTo(HaveKey("client_secret"))
+			})
+
+			It("can authenticate with a private key instead of a secret", func() {
+				key, _ := rsa.GenerateKey(rand.Reader, 2048)
+				keyPath := path.Join(homeDir, "client-key.pem")
+				ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600)
+
+				server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+					RespondWith(http.StatusOK, opaqueTokenResponseJson),
+					VerifyFormKV("client_id", "admin"),
+					VerifyFormKV("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"),
+					VerifyFormKV("grant_type", "client_credentials"),
+				))
+
+				session := runCommand("get-client-credentials-token", "admin", "--auth-method", "private_key_jwt", "--private-key", keyPath, "--key-id", "key-1")
+
+				Eventually(session).Should(Exit(0))
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+				Expect(server.ReceivedRequests()[0].Form.Get("client_assertion")).NotTo(BeEmpty())
+				Expect(config.ReadConfig().GetActiveContext().AccessToken).To(Equal("bc4885d950854fed9a938e96b13ca519"))
+			})
+
+			It("displays an error when the private key cannot be read", func() {
+				session := runCommand("get-client-credentials-token", "admin", "--auth-method", "private_key_jwt", "--private-key", path.Join(homeDir, "missing.pem"))
+
+				Eventually(session).Should(Exit(1))
+				Expect(session.Err).To(Say("The private key could not be read from"))
+			})
+
+			It("requires a private key for private_key_jwt", func() {
+				session := runCommand("get-client-credentials-token", "admin", "--auth-method", "private_key_jwt")
+
+				Eventually(session).Should(Exit(1))
+				Expect(session.Err).To(Say("Missing argument `private-key` must be specified."))
+			})
+
+			It("displays error when unknown authentication method is passed", func() {
+				session := runCommand("get-client-credentials-token", "admin", "-s", "adminsecret", "--auth-method", "bogus")
+
+				Eventually(session).Should(Exit(1))
+				Expect(session.Err).To(Say(`The client authentication method "bogus" is unknown.`))
+			})
+		})
 	})
 
 	Describe("when the token request fails", func() {
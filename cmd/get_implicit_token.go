@@ -44,6 +44,7 @@ func ImplicitTokenCommandRun(doneRunning chan bool, clientId string, implicitImp
 	implicitImp.Authorize()
 	tokenResponse := <-implicitImp.Done()
 	addImplicitTokenToContext(clientId, tokenResponse, log)
+	warnOnScopeMismatch(*log, requestedScope(scope), tokenResponse.Scope)
 	doneRunning <- true
 }
 
@@ -57,8 +58,9 @@ var getImplicitToken = &cobra.Command{
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		done := make(chan bool)
-		baseUrl := GetSavedConfig().GetActiveTarget().BaseUrl
-		implicitImp := cli.NewImplicitClientImpersonator(args[0], baseUrl, tokenFormat, scope, port, log, open.Run)
+		target := GetSavedConfig().GetActiveTarget()
+		implicitImp := cli.NewImplicitClientImpersonator(args[0], target.BaseUrl, tokenFormat, requestedScope(scope), port, log, open.Run)
+		implicitImp.AuthorizationEndpoint = target.AuthorizationEndpoint()
 		go ImplicitTokenCommandRun(done, args[0], implicitImp, GetLogger())
 		<-done
 	},
@@ -66,7 +68,7 @@ var getImplicitToken = &cobra.Command{
 
 func init() {
 	getImplicitToken.Flags().IntVarP(&port, "port", "", 0, "port on which to run local callback server")
-	getImplicitToken.Flags().StringVarP(&scope, "scope", "", "openid", "comma-separated scopes to request in token")
+	getImplicitToken.Flags().StringVarP(&scope, "scope", "", "openid", "comma or space separated scopes to request in token")
 	getImplicitToken.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
 	getImplicitToken.Annotations = make(map[string]string)
 	getImplicitToken.Annotations[TOKEN_CATEGORY] = "true"
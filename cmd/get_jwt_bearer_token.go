@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"errors"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+var assertion string
+
+func readAssertion(path string) (string, error) {
+	var bytes []byte
+	var err error
+	if path == "-" {
+		bytes, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		bytes, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return "", errors.New("The assertion could not be read from " + path + ".")
+	}
+
+	value := strings.TrimSpace(string(bytes))
+	if value == "" {
+		return "", errors.New("The assertion read from " + path + " was empty.")
+	}
+	return value, nil
+}
+
+func GetJwtBearerTokenValidations(cfg uaa.Config, args []string, clientSecret, assertion string) error {
+	if err := EnsureTargetInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) < 1 {
+		return MissingArgumentError("client_id")
+	}
+	if err := validateClientAuthentication(clientSecret); err != nil {
+		return err
+	}
+	if assertion == "" {
+		return MissingArgumentWithExplanationError("assertion", `Provide a path to a file containing the JWT, or "-" to read it from stdin.`)
+	}
+	return validateTokenFormatError(tokenFormat)
+}
+
+func GetJwtBearerTokenCmd(cfg uaa.Config, httpClient *http.Client, clientId, clientSecret, assertionPath, tokenFormat, scope string) error {
+	jwt, err := readAssertion(assertionPath)
+	if err != nil {
+		return err
+	}
+
+	clientAuth, err := buildClientAuthentication(clientId, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	jwtBearerClient := uaa.JwtBearerClient{ClientId: clientId, ClientSecret: clientSecret, ClientAuth: clientAuth, Scope: requestedScope(scope)}
+	tokenResponse, err := jwtBearerClient.RequestToken(httpClient, cfg, uaa.TokenFormat(tokenFormat), jwt)
+	if err != nil {
+		return errors.New("An error occurred while fetching token.")
+	}
+
+	ctx := uaa.UaaContext{
+		GrantType:     uaa.JWT_BEARER,
+		ClientId:      clientId,
+		TokenResponse: tokenResponse,
+	}
+	cfg.AddContext(ctx)
+	config.WriteConfig(cfg)
+	log.Info("Access token successfully fetched and added to context.")
+	warnOnScopeMismatch(log, requestedScope(scope), tokenResponse.Scope)
+	return nil
+}
+
+var getJwtBearerTokenCmd = &cobra.Command{
+	Use:   "get-jwt-bearer-token CLIENT_ID -s CLIENT_SECRET --assertion FILE|-",
+	Short: "Obtain an access token by exchanging a JWT using the jwt-bearer grant type",
+	Long:  help.JwtBearerGrant(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(GetJwtBearerTokenValidations(cfg, args, clientSecret, assertion), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyErrorsWithRetry(GetJwtBearerTokenCmd(cfg, GetHttpClient(), args[0], clientSecret, assertion, tokenFormat, tokenScope), cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getJwtBearerTokenCmd)
+	getJwtBearerTokenCmd.Annotations = make(map[string]string)
+	getJwtBearerTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
+	getJwtBearerTokenCmd.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "client secret")
+	addClientAuthenticationFlags(getJwtBearerTokenCmd, uaa.CLIENT_SECRET_POST)
+	getJwtBearerTokenCmd.Flags().StringVarP(&assertion, "assertion", "", "", `path to a file containing the JWT to exchange, or "-" to read from stdin`)
+	addScopeFlag(getJwtBearerTokenCmd)
+	getJwtBearerTokenCmd.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
+}
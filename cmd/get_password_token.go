@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"code.cloudfoundry.org/uaa-cli/cli"
 	"code.cloudfoundry.org/uaa-cli/config"
 	"code.cloudfoundry.org/uaa-cli/help"
 	"code.cloudfoundry.org/uaa-cli/uaa"
@@ -9,13 +10,28 @@ import (
 	"net/http"
 )
 
-func GetPasswordTokenValidations(cfg uaa.Config, args []string, clientSecret, username, password string) error {
+// Password token flags
+var (
+	passcode string
+	mfaCode  string
+)
+
+func GetPasswordTokenValidations(cfg uaa.Config, args []string, clientSecret, username, password, passcode string) error {
 	if err := EnsureTargetInConfig(cfg); err != nil {
 		return err
 	}
 	if len(args) < 1 {
 		return MissingArgumentError("client_id")
 	}
+	if err := validateClientAuthMethod(); err != nil {
+		return err
+	}
+	if passcode != "" {
+		if username != "" || password != "" {
+			return errors.New("A passcode replaces the username and password. Use either --passcode or --username and --password.")
+		}
+		return validateTokenFormatError(tokenFormat)
+	}
 	if password == "" {
 		return MissingArgumentError("password")
 	}
@@ -25,28 +41,70 @@ func GetPasswordTokenValidations(cfg uaa.Config, args []string, clientSecret, us
 	return validateTokenFormatError(tokenFormat)
 }
 
-func GetPasswordTokenCmd(cfg uaa.Config, httpClient *http.Client, clientId, clientSecret, username, password, tokenFormat string) error {
-	requestedType := uaa.TokenFormat(tokenFormat)
+func GetPasswordTokenCmd(cfg uaa.Config, httpClient *http.Client, clientId, clientSecret, username, password, passcode, mfaCode, tokenFormat, scope, origin string) error {
+	clientAuth, err := buildClientAuthentication(clientId, clientSecret)
+	if err != nil {
+		return err
+	}
 
 	ccClient := uaa.ResourceOwnerPasswordClient{
 		ClientId:     clientId,
 		ClientSecret: clientSecret,
+		ClientAuth:   clientAuth,
 		Username:     username,
 		Password:     password,
+		Passcode:     passcode,
+		MfaCode:      mfaCode,
+		Scope:        requestedScope(scope),
+		Origin:       origin,
 	}
+	return fetchPasswordToken(cfg, httpClient, ccClient, tokenFormat)
+}
+
+// fetchPasswordToken requests a token with the password grant, asking for an
+// MFA code if the UAA requires one, and saves it in the active context.
+func fetchPasswordToken(cfg uaa.Config, httpClient *http.Client, ccClient uaa.ResourceOwnerPasswordClient, tokenFormat string) error {
+	requestedType := uaa.TokenFormat(tokenFormat)
 	tokenResponse, err := ccClient.RequestToken(httpClient, cfg, requestedType)
+	if mfaErr, ok := err.(uaa.MfaError); ok && mfaErr.Required {
+		// Whether a user has MFA enabled is only known once the UAA rejects
+		// their password, so the code is asked for then, if there is a user
+		// to ask.
+		code, promptErr := cli.InteractivePrompt{Prompt: "MFA Code"}.Get()
+		if promptErr != nil || code == "" {
+			return mfaErr
+		}
+		ccClient.MfaCode = code
+		tokenResponse, err = ccClient.RequestToken(httpClient, cfg, requestedType)
+	}
+	if mfaErr, ok := err.(uaa.MfaError); ok {
+		return mfaErr
+	}
 	if err != nil {
 		return errors.New("An error occurred while fetching token.")
 	}
 
 	activeContext := cfg.GetActiveContext()
-	activeContext.ClientId = clientId
+	activeContext.ClientId = ccClient.ClientId
 	activeContext.GrantType = uaa.PASSWORD
-	activeContext.Username = username
+	activeContext.Username = ccClient.Username
+	if activeContext.Username == "" {
+		// A passcode does not say whose it is, but a JWT does.
+		activeContext.Username = tokenUsername(tokenResponse.AccessToken)
+	}
 	activeContext.TokenResponse = tokenResponse
 	cfg.AddContext(activeContext)
+	if activeContext.Username == "" {
+		// Opaque tokens have no claims, so the user is looked up with the
+		// new token instead.
+		if userinfo, err := uaa.Me(httpClient, cfg); err == nil {
+			activeContext.Username = userinfo.Username
+			cfg.AddContext(activeContext)
+		}
+	}
 	config.WriteConfig(cfg)
 	log.Info("Access token successfully fetched and added to context.")
+	warnOnScopeMismatch(log, ccClient.Scope, tokenResponse.Scope)
 	return nil
 }
 
@@ -56,11 +114,11 @@ var getPasswordToken = &cobra.Command{
 	Long:  help.PasswordGrant(),
 	PreRun: func(cmd *cobra.Command, args []string) {
 		cfg := GetSavedConfig()
-		NotifyValidationErrors(GetPasswordTokenValidations(cfg, args, clientSecret, username, password), cmd, log)
+		NotifyValidationErrors(GetPasswordTokenValidations(cfg, args, clientSecret, username, password, passcode), cmd, log)
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := GetSavedConfig()
-		NotifyErrorsWithRetry(GetPasswordTokenCmd(cfg, GetHttpClient(), args[0], clientSecret, username, password, tokenFormat), cfg, log)
+		NotifyErrorsWithRetry(GetPasswordTokenCmd(cfg, GetHttpClient(), args[0], clientSecret, username, password, passcode, mfaCode, tokenFormat, tokenScope, origin), cfg, log)
 	},
 }
 
@@ -69,7 +127,12 @@ func init() {
 	getPasswordToken.Annotations = make(map[string]string)
 	getPasswordToken.Annotations[TOKEN_CATEGORY] = "true"
 	getPasswordToken.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "client secret")
+	addClientAuthenticationFlags(getPasswordToken, uaa.CLIENT_SECRET_POST)
 	getPasswordToken.Flags().StringVarP(&username, "username", "u", "", "username")
 	getPasswordToken.Flags().StringVarP(&password, "password", "p", "", "user password")
+	getPasswordToken.Flags().StringVarP(&mfaCode, "mfa-code", "", "", "multi-factor authentication code from the user's authenticator app")
+	getPasswordToken.Flags().StringVarP(&passcode, "passcode", "", "", "one-time passcode from the login server's /passcode page, in place of username and password")
+	addScopeFlag(getPasswordToken)
+	getPasswordToken.Flags().StringVarP(&origin, "origin", "o", "", "origin of the identity provider which should authenticate the user, sent as login_hint")
 	getPasswordToken.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
 }
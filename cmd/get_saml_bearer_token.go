@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"errors"
+	"github.com/spf13/cobra"
+	"net/http"
+)
+
+var entityId string
+
+func GetSamlBearerTokenValidations(cfg uaa.Config, args []string, clientSecret, assertion string) error {
+	if err := EnsureTargetInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) < 1 {
+		return MissingArgumentError("client_id")
+	}
+	if err := validateClientAuthentication(clientSecret); err != nil {
+		return err
+	}
+	if assertion == "" {
+		return MissingArgumentWithExplanationError("assertion", `Provide a path to a file containing the SAML assertion, or "-" to read it from stdin.`)
+	}
+	return validateTokenFormatError(tokenFormat)
+}
+
+func GetSamlBearerTokenCmd(cfg uaa.Config, httpClient *http.Client, clientId, clientSecret, assertionPath, entityId, tokenFormat, scope string) error {
+	samlAssertion, err := readAssertion(assertionPath)
+	if err != nil {
+		return err
+	}
+
+	if entityId == "" {
+		info, err := uaa.Info(httpClient, cfg)
+		if err != nil {
+			return errors.New("The entity ID of the targeted UAA could not be determined. Specify it with --entity-id.")
+		}
+		entityId = info.EntityId
+	}
+
+	clientAuth, err := buildClientAuthentication(clientId, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	samlClient := uaa.Saml2BearerClient{ClientId: clientId, ClientSecret: clientSecret, ClientAuth: clientAuth, EntityId: entityId, Scope: requestedScope(scope)}
+	tokenResponse, err := samlClient.RequestToken(httpClient, cfg, uaa.TokenFormat(tokenFormat), samlAssertion)
+	if err != nil {
+		return errors.New("An error occurred while fetching token.")
+	}
+
+	ctx := uaa.UaaContext{
+		GrantType:     uaa.SAML2_BEARER,
+		ClientId:      clientId,
+		TokenResponse: tokenResponse,
+	}
+	cfg.AddContext(ctx)
+	config.WriteConfig(cfg)
+	log.Info("Access token successfully fetched and added to context.")
+	warnOnScopeMismatch(log, requestedScope(scope), tokenResponse.Scope)
+	return nil
+}
+
+var getSamlBearerTokenCmd = &cobra.Command{
+	Use:   "get-saml-bearer-token CLIENT_ID -s CLIENT_SECRET --assertion FILE|-",
+	Short: "Obtain an access token by exchanging a SAML assertion using the saml2-bearer grant type",
+	Long:  help.SamlBearerGrant(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(GetSamlBearerTokenValidations(cfg, args, clientSecret, assertion), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyErrorsWithRetry(GetSamlBearerTokenCmd(cfg, GetHttpClient(), args[0], clientSecret, assertion, entityId, tokenFormat, tokenScope), cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getSamlBearerTokenCmd)
+	getSamlBearerTokenCmd.Annotations = make(map[string]string)
+	getSamlBearerTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
+	getSamlBearerTokenCmd.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "client secret")
+	addClientAuthenticationFlags(getSamlBearerTokenCmd, uaa.CLIENT_SECRET_POST)
+	getSamlBearerTokenCmd.Flags().StringVarP(&assertion, "assertion", "", "", `path to a file containing the SAML assertion (XML or base64), or "-" to read from stdin`)
+	getSamlBearerTokenCmd.Flags().StringVarP(&entityId, "entity-id", "", "", "entity ID of the UAA service provider the assertion was issued for (defaults to the entityID reported by /info)")
+	addScopeFlag(getSamlBearerTokenCmd)
+	getSamlBearerTokenCmd.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
+}
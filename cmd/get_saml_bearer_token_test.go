@@ -0,0 +1,114 @@
+package cmd_test
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+	"encoding/base64"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+)
+
+var _ = Describe("GetSamlBearerToken", func() {
+	const samlAssertion = `<saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion" ID="_1">woodstock</saml2:Assertion>`
+
+	var opaqueTokenResponseJson = `{
+	  "access_token" : "bc4885d950854fed9a938e96b13ca519",
+	  "token_type" : "bearer",
+	  "expires_in" : 43199,
+	  "scope" : "openid",
+	  "jti" : "bc4885d950854fed9a938e96b13ca519"
+	}`
+
+	var assertionPath string
+	var encodedAssertion = base64.RawURLEncoding.EncodeToString([]byte(samlAssertion))
+
+	BeforeEach(func() {
+		assertionPath = path.Join(homeDir, "assertion.xml")
+		ioutil.WriteFile(assertionPath, []byte(samlAssertion), 0600)
+	})
+
+	Describe("and a target was previously set", func() {
+		BeforeEach(func() {
+			config.WriteConfig(NewConfigWithServerURL(server.URL()))
+		})
+
+		It("uses the entity id from /info and updates the saved context", func() {
+			server.RouteToHandler("GET", "/info",
+				RespondWith(http.StatusOK, `{"entityID": "cloudfoundry-saml-login"}`),
+			)
+			server.RouteToHandler("POST", "/oauth/token/alias/cloudfoundry-saml-login", CombineHandlers(
+				RespondWith(http.StatusOK, opaqueTokenResponseJson),
+				VerifyFormKV("client_id", "federated"),
+				VerifyFormKV("client_secret", "federatedsecret"),
+				VerifyFormKV("grant_type", "urn:ietf:params:oauth:grant-type:saml2-bearer"),
+				VerifyFormKV("assertion", encodedAssertion),
+			))
+
+			session := runCommand("get-saml-bearer-token", "federated", "-s", "federatedsecret", "--assertion", assertionPath)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Access token successfully fetched and added to context."))
+			Expect(config.ReadConfig().GetActiveContext().AccessToken).To(Equal("bc4885d950854fed9a938e96b13ca519"))
+			Expect(config.ReadConfig().GetActiveContext().ClientId).To(Equal("federated"))
+			Expect(config.ReadConfig().GetActiveContext().GrantType).To(Equal(SAML2_BEARER))
+		})
+
+		It("uses the entity id given with --entity-id and reads the assertion from stdin", func() {
+			server.RouteToHandler("POST", "/oauth/token/alias/login.example.com", CombineHandlers(
+				RespondWith(http.StatusOK, opaqueTokenResponseJson),
+				VerifyFormKV("assertion", encodedAssertion),
+			))
+
+			session := runCommandWithStdin(strings.NewReader(samlAssertion), "get-saml-bearer-token", "federated", "-s", "federatedsecret", "--assertion", "-", "--entity-id", "login.example.com")
+
+			Eventually(session).Should(Exit(0))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+			Expect(config.ReadConfig().GetActiveContext().GrantType).To(Equal(SAML2_BEARER))
+		})
+
+		It("displays an error when the token request fails", func() {
+			server.RouteToHandler("POST", "/oauth/token/alias/login.example.com",
+				RespondWith(http.StatusUnauthorized, `{"error":"invalid_grant"}`),
+			)
+
+			session := runCommand("get-saml-bearer-token", "federated", "-s", "federatedsecret", "--assertion", assertionPath, "--entity-id", "login.example.com")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("An error occurred while fetching token."))
+		})
+	})
+
+	Describe("Validations", func() {
+		BeforeEach(func() {
+			config.WriteConfig(NewConfigWithServerURL("http://localhost"))
+		})
+
+		It("requires a client id", func() {
+			session := runCommand("get-saml-bearer-token")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `client_id` must be specified."))
+		})
+
+		It("requires a client secret", func() {
+			session := runCommand("get-saml-bearer-token", "federated", "--assertion", assertionPath)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `client_secret` must be specified."))
+		})
+
+		It("requires an assertion", func() {
+			session := runCommand("get-saml-bearer-token", "federated", "-s", "federatedsecret")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `assertion` must be specified."))
+		})
+	})
+})
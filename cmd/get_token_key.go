@@ -2,18 +2,24 @@ package cmd
 
 import (
 	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
 	"code.cloudfoundry.org/uaa-cli/uaa"
 	"github.com/spf13/cobra"
+	"io"
 	"net/http"
+	"os"
 )
 
-func GetTokenKeyCmd(client *http.Client, config uaa.Config) error {
+func GetTokenKeyCmd(client *http.Client, config uaa.Config, details io.Writer, output, dir string) error {
 	key, err := uaa.TokenKey(client, config)
 
 	if err != nil {
 		return err
 	}
 
+	if output != "json" {
+		return printTokenKeys(cli.NewJsonPrinter(log), details, []uaa.JWK{key}, output, dir)
+	}
 	return cli.NewJsonPrinter(log).Print(key)
 }
 
@@ -21,12 +27,14 @@ var getTokenKeyCmd = &cobra.Command{
 	Use:     "get-token-key",
 	Short:   "View the key for validating UAA's JWT token signatures",
 	Aliases: []string{"token-key"},
+	Long:    help.TokenKey(),
 	PreRun: func(cmd *cobra.Command, args []string) {
 		cfg := GetSavedConfig()
 		NotifyValidationErrors(EnsureTargetInConfig(cfg), cmd, log)
+		NotifyValidationErrors(TokenKeyOutputValidations(tokenKeyOutput, tokenKeyDir), cmd, log)
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		NotifyErrorsWithRetry(GetTokenKeyCmd(GetHttpClient(), GetSavedConfig()), GetSavedConfig(), log)
+		NotifyErrorsWithRetry(GetTokenKeyCmd(GetHttpClient(), GetSavedConfig(), os.Stderr, tokenKeyOutput, tokenKeyDir), GetSavedConfig(), log)
 	},
 }
 
@@ -34,4 +42,5 @@ func init() {
 	RootCmd.AddCommand(getTokenKeyCmd)
 	getTokenKeyCmd.Annotations = make(map[string]string)
 	getTokenKeyCmd.Annotations[TOKEN_CATEGORY] = "true"
+	addTokenKeyOutputFlags(getTokenKeyCmd)
 }
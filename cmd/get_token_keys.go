@@ -2,18 +2,24 @@ package cmd
 
 import (
 	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
 	"code.cloudfoundry.org/uaa-cli/uaa"
 	"github.com/spf13/cobra"
+	"io"
 	"net/http"
+	"os"
 )
 
-func GetTokenKeysCmd(client *http.Client, config uaa.Config) error {
+func GetTokenKeysCmd(client *http.Client, config uaa.Config, details io.Writer, output, dir string) error {
 	key, err := uaa.TokenKeys(client, config)
 
 	if err != nil {
 		return err
 	}
 
+	if output != "json" {
+		return printTokenKeys(cli.NewJsonPrinter(log), details, key, output, dir)
+	}
 	return cli.NewJsonPrinter(log).Print(key)
 }
 
@@ -21,12 +27,14 @@ var getTokenKeysCmd = &cobra.Command{
 	Use:     "get-token-keys",
 	Short:   "View all keys the UAA has used to sign JWT tokens",
 	Aliases: []string{"token-keys"},
+	Long:    help.TokenKeys(),
 	PreRun: func(cmd *cobra.Command, args []string) {
 		cfg := GetSavedConfig()
 		NotifyValidationErrors(EnsureTargetInConfig(cfg), cmd, log)
+		NotifyValidationErrors(TokenKeyOutputValidations(tokenKeyOutput, tokenKeyDir), cmd, log)
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		NotifyErrorsWithRetry(GetTokenKeysCmd(GetHttpClient(), GetSavedConfig()), GetSavedConfig(), log)
+		NotifyErrorsWithRetry(GetTokenKeysCmd(GetHttpClient(), GetSavedConfig(), os.Stderr, tokenKeyOutput, tokenKeyDir), GetSavedConfig(), log)
 	},
 }
 
@@ -34,4 +42,5 @@ func init() {
 	RootCmd.AddCommand(getTokenKeysCmd)
 	getTokenKeysCmd.Annotations = make(map[string]string)
 	getTokenKeysCmd.Annotations[TOKEN_CATEGORY] = "true"
+	addTokenKeyOutputFlags(getTokenKeysCmd)
 }
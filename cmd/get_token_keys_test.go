@@ -4,12 +4,17 @@ import (
 	"code.cloudfoundry.org/uaa-cli/cmd"
 	"code.cloudfoundry.org/uaa-cli/config"
 	"code.cloudfoundry.org/uaa-cli/uaa"
+	"encoding/pem"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
 	. "github.com/onsi/gomega/gexec"
 	. "github.com/onsi/gomega/ghttp"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 )
 
 var _ = Describe("GetTokenKeys", func() {
@@ -98,6 +103,105 @@ var _ = Describe("GetTokenKeys", func() {
 		})
 	})
 
+	Describe("exporting keys", func() {
+		const publicKeyPem = "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA0m59l2u9iDnMbrXHfqkO\nrn2dVQ3vfBJqcDuFUK03d+1PZGbVlNCqnkpIJ8syFppW8ljnWweP7+LiWpRoz0I7\nfYb3d8TjhV86Y997Fl4DBrxgM6KTJOuE/uxnoDhZQ14LgOU2ckXjOzOdTsnGMKQB\nLCl0vpcXBtFLMaSbpv1ozi8h7DJyVZ6EnFQZUWGdgTMhDrmqevfx95U/16c5WBDO\nkqwIn7Glry9n9Suxygbf8g5AzpWcusZgDLIIZ7JTUldBb8qU2a0Dl4mvLZOn4wPo\njfj9Cw2QICsc5+Pwf21fP+hzf+1WSRHbnYv8uanRO0gZ8ekGaghM/2H6gqJbo2nI\nJwIDAQAB\n-----END PUBLIC KEY-----\n"
+		keyList := `{"keys": [{
+		  "kty" : "RSA",
+		  "e" : "AQAB",
+		  "use" : "sig",
+		  "kid" : "testKey",
+		  "alg" : "RS256",
+		  "n" : "ANJufZdrvYg5zG61x36pDq59nVUN73wSanA7hVCtN3ftT2Rm1ZTQqp5KSCfLMhaaVvJY51sHj-_i4lqUaM9CO32G93fE44VfOmPfexZeAwa8YDOikyTrhP7sZ6A4WUNeC4DlNnJF4zsznU7JxjCkASwpdL6XFwbRSzGkm6b9aM4vIewyclWehJxUGVFhnYEzIQ65qnr38feVP9enOVgQzpKsCJ-xpa8vZ_UrscoG3_IOQM6VnLrGYAyyCGeyU1JXQW_KlNmtA5eJry2Tp-MD6I34_QsNkCArHOfj8H9tXz_oc3_tVkkR252L_Lmp0TtIGfHpBmoITP9h-oKiW6NpyCc"
+		},
+		{
+		  "kty" : "MAC",
+		  "alg" : "HS256",
+		  "value" : "key",
+		  "kid" : "legacy"
+		}]}`
+
+		var dir string
+
+		BeforeEach(func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+			server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, keyList))
+			dir, _ = ioutil.TempDir("", "uaa-token-keys")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("prints PEM public keys with their fingerprints", func() {
+			session := runCommand("get-token-keys", "--output", "pem")
+
+			Eventually(session).Should(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal(publicKeyPem))
+			Expect(session.Err).To(Say("kid: testKey, fingerprint: SHA256:[0-9A-F:]{95}"))
+			Expect(session.Err).NotTo(Say("legacy"))
+		})
+
+		It("prints a standard JWKS document", func() {
+			session := runCommand("get-token-keys", "--output", "jwks")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Err).To(Say("kid: testKey, fingerprint: SHA256:[0-9A-F:]{95}"))
+			Expect(session.Out.Contents()).To(MatchJSON(`{"keys": [{
+			  "kty" : "RSA",
+			  "kid" : "testKey",
+			  "use" : "sig",
+			  "alg" : "RS256",
+			  "n" : "0m59l2u9iDnMbrXHfqkOrn2dVQ3vfBJqcDuFUK03d-1PZGbVlNCqnkpIJ8syFppW8ljnWweP7-LiWpRoz0I7fYb3d8TjhV86Y997Fl4DBrxgM6KTJOuE_uxnoDhZQ14LgOU2ckXjOzOdTsnGMKQBLCl0vpcXBtFLMaSbpv1ozi8h7DJyVZ6EnFQZUWGdgTMhDrmqevfx95U_16c5WBDOkqwIn7Glry9n9Suxygbf8g5AzpWcusZgDLIIZ7JTUldBb8qU2a0Dl4mvLZOn4wPojfj9Cw2QICsc5-Pwf21fP-hzf-1WSRHbnYv8uanRO0gZ8ekGaghM_2H6gqJbo2nIJw",
+			  "e" : "AQAB"
+			}]}`))
+		})
+
+		It("writes one PEM file per kid to a directory", func() {
+			session := runCommand("get-token-keys", "--output", "pem", "--dir", dir)
+
+			Eventually(session).Should(Exit(0))
+			contents, err := ioutil.ReadFile(filepath.Join(dir, "testKey.pem"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal(publicKeyPem))
+			Expect(session.Out).To(Say(`"kid": "testKey"`))
+			Expect(session.Out).To(Say(`"fingerprint": "SHA256:`))
+			Expect(session.Out).To(Say(`"file": ".*testKey.pem"`))
+		})
+
+		It("writes DER files", func() {
+			session := runCommand("get-token-keys", "--output", "der", "--dir", dir)
+
+			Eventually(session).Should(Exit(0))
+			contents, err := ioutil.ReadFile(filepath.Join(dir, "testKey.der"))
+			Expect(err).NotTo(HaveOccurred())
+			block, _ := pem.Decode([]byte(publicKeyPem))
+			Expect(contents).To(Equal(block.Bytes))
+		})
+
+		It("exports the current key with get-token-key", func() {
+			server.RouteToHandler("GET", "/token_key", RespondWith(http.StatusOK, `{"kty": "RSA", "kid": "testKey", "value": `+strconv.Quote(publicKeyPem)+`}`))
+
+			session := runCommand("get-token-key", "--output", "pem", "--dir", dir)
+
+			Eventually(session).Should(Exit(0))
+			Expect(filepath.Join(dir, "testKey.pem")).To(BeAnExistingFile())
+		})
+
+		It("requires a directory for DER output", func() {
+			session := runCommand("get-token-keys", "--output", "der")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("DER keys are binary and can only be written to files. Use --dir to choose a directory."))
+		})
+
+		It("rejects unknown output formats", func() {
+			session := runCommand("get-token-keys", "--output", "yaml")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The output format yaml is not supported."))
+		})
+	})
+
 	Describe("Validations", func() {
 		It("it requires a target to have been set", func() {
 			config.WriteConfig(uaa.NewConfig())
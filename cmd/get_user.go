@@ -1,19 +1,112 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
 	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
 	"code.cloudfoundry.org/uaa-cli/uaa"
-	"errors"
 	"github.com/spf13/cobra"
 )
 
-func GetUserCmd(um uaa.UserManager, printer cli.Printer, username, origin, attributes string) error {
+var showStatus bool
+
+// userWithStatus adds a human readable account status to a user.
+type userWithStatus struct {
+	uaa.ScimUser
+	Status accountStatus `json:"status"`
+}
+
+type accountStatus struct {
+	PasswordLastModified string `json:"passwordLastModified,omitempty"`
+	LastLogon            string `json:"lastLogon,omitempty"`
+	PreviousLogon        string `json:"previousLogon,omitempty"`
+	LockoutPolicy        string `json:"lockoutPolicy,omitempty"`
+}
+
+// humanDuration rounds d down to its largest whole unit, e.g. "3 days".
+func humanDuration(d time.Duration) string {
+	units := []struct {
+		name   string
+		length time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	}
+	if d < 0 {
+		d = 0
+	}
+	for _, unit := range units {
+		if n := int64(d / unit.length); n > 0 || unit.length == time.Second {
+			if n == 1 {
+				return fmt.Sprintf("1 %v", unit.name)
+			}
+			return fmt.Sprintf("%v %vs", n, unit.name)
+		}
+	}
+	return ""
+}
+
+func humanTime(t time.Time, now time.Time) string {
+	return fmt.Sprintf("%v (%v ago)", t.UTC().Format(time.RFC3339), humanDuration(now.Sub(t)))
+}
+
+func describeLockoutPolicy(policy *uaa.LockoutPolicy) string {
+	if policy == nil || policy.LockoutAfterFailures < 0 || policy.LockoutPeriodSeconds < 0 || policy.CountFailuresWithin < 0 {
+		return "the default policy of the UAA"
+	}
+	return fmt.Sprintf("locked for %v after %v failed logins within %v",
+		humanDuration(time.Duration(policy.LockoutPeriodSeconds)*time.Second),
+		policy.LockoutAfterFailures,
+		humanDuration(time.Duration(policy.CountFailuresWithin)*time.Second))
+}
+
+func statusOfUser(user uaa.ScimUser, lockoutPolicy *uaa.LockoutPolicy, policyErr error, now time.Time) accountStatus {
+	status := accountStatus{}
+	if modified, err := time.Parse(time.RFC3339, user.PasswordLastModified); err == nil {
+		status.PasswordLastModified = humanTime(modified, now)
+	}
+	if user.LastLogonTime > 0 {
+		status.LastLogon = humanTime(time.Unix(0, int64(user.LastLogonTime)*int64(time.Millisecond)), now)
+	}
+	if user.PreviousLogonTime > 0 {
+		status.PreviousLogon = humanTime(time.Unix(0, int64(user.PreviousLogonTime)*int64(time.Millisecond)), now)
+	}
+	if policyErr == nil && lockoutApplies(user) {
+		status.LockoutPolicy = describeLockoutPolicy(lockoutPolicy)
+	}
+	return status
+}
+
+// lockoutApplies says whether the UAA checks the user's password itself, and
+// so whether the lockout policy is relevant to them.
+func lockoutApplies(user uaa.ScimUser) bool {
+	return user.Origin == "" || user.Origin == "uaa"
+}
+
+func GetUserCmd(httpClient *http.Client, config uaa.Config, printer cli.Printer, username, origin, attributes string, withStatus bool) error {
+	um := uaa.UserManager{httpClient, config}
 	user, err := um.GetByUsername(username, origin, attributes)
 	if err != nil {
 		return err
 	}
 
-	return printer.Print(user)
+	if !withStatus {
+		return printer.Print(user)
+	}
+	lockoutPolicy, policyErr := uaa.GetLockoutPolicy(httpClient, config)
+	if err := printer.Print(userWithStatus{user, statusOfUser(user, lockoutPolicy, policyErr, time.Now())}); err != nil {
+		return err
+	}
+	if policyErr != nil && lockoutApplies(user) {
+		log.Warn("The lockout policy of the zone could not be read: " + describeError(policyErr))
+	}
+	return nil
 }
 
 func GetUserValidations(cfg uaa.Config, args []string) error {
@@ -30,13 +123,13 @@ func GetUserValidations(cfg uaa.Config, args []string) error {
 var getUserCmd = &cobra.Command{
 	Use:   "get-user USERNAME",
 	Short: "Look up a user by username",
+	Long:  help.GetUser(),
 	PreRun: func(cmd *cobra.Command, args []string) {
 		NotifyValidationErrors(GetUserValidations(GetSavedConfig(), args), cmd, log)
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := GetSavedConfig()
-		um := uaa.UserManager{GetHttpClient(), cfg}
-		err := GetUserCmd(um, cli.NewJsonPrinter(log), args[0], origin, attributes)
+		err := GetUserCmd(GetHttpClient(), cfg, cli.NewJsonPrinter(log), args[0], origin, attributes, showStatus)
 		NotifyErrorsWithRetry(err, cfg, log)
 	},
 }
@@ -48,5 +141,6 @@ func init() {
 
 	getUserCmd.Flags().StringVarP(&origin, "origin", "o", "", `The identity provider in which to search. Examples: uaa, ldap, etc. `)
 	getUserCmd.Flags().StringVarP(&attributes, "attributes", "a", "", `include only these comma-separated user attributes to improve query performance`)
+	getUserCmd.Flags().BoolVarP(&showStatus, "status", "", false, "show when the password was last changed, the last logins and the lockout policy in human form")
 	getUserCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain in find the user")
 }
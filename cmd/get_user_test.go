@@ -59,6 +59,44 @@ var _ = Describe("GetUser", func() {
 		Eventually(session).Should(Exit(0))
 	})
 
+	Describe("--status", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/Users", RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{
+				Username:             "woodstock",
+				Origin:               "uaa",
+				PasswordLastModified: "2017-08-15T16:54:15.000Z",
+				LastLogonTime:        1502816055768,
+			})))
+		})
+
+		It("shows password, logon and lockout information in human form", func() {
+			server.RouteToHandler("GET", "/identity-providers", CombineHandlers(
+				VerifyRequest("GET", "/identity-providers", "rawConfig=true"),
+				RespondWith(http.StatusOK, `[{"originKey": "uaa", "config": {"lockoutPolicy": {"lockoutPeriodSeconds": 300, "lockoutAfterFailures": 5, "countFailuresWithin": 3600}}}]`),
+			))
+
+			session := runCommand("get-user", "woodstock", "--status")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say(`"userName": "woodstock"`))
+			Expect(session.Out).To(Say(`"status": {`))
+			Expect(session.Out).To(Say(`"passwordLastModified": "2017-08-15T16:54:15Z \(\d+ days ago\)"`))
+			Expect(session.Out).To(Say(`"lastLogon": "2017-08-15T16:54:15Z \(\d+ days ago\)"`))
+			Expect(session.Out).To(Say(`"lockoutPolicy": "locked for 5 minutes after 5 failed logins within 1 hour"`))
+		})
+
+		It("warns that the lockout policy could not be read", func() {
+			server.RouteToHandler("GET", "/identity-providers", RespondWith(http.StatusForbidden, `{"error": "insufficient_scope"}`))
+
+			session := runCommand("get-user", "woodstock", "--status")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say(`"passwordLastModified": "2017-08-15T16:54:15Z`))
+			Expect(session.Out).NotTo(Say(`lockoutPolicy`))
+			Expect(session.Out).To(Say("The lockout policy of the zone could not be read"))
+		})
+	})
+
 	Describe("validations", func() {
 		It("requires a target", func() {
 			err := GetUserValidations(uaa.Config{}, []string{})
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"errors"
+	"github.com/spf13/cobra"
+	"net/http"
+)
+
+func GetUserTokenValidations(cfg uaa.Config, args []string) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) < 1 {
+		return MissingArgumentError("target_client_id")
+	}
+	if cfg.GetActiveContext().AccessToken == "" {
+		return errors.New("An access_token was not found in the active context.")
+	}
+	return validateTokenFormatError(tokenFormat)
+}
+
+func GetUserTokenCmd(cfg uaa.Config, httpClient *http.Client, log cli.Logger, targetClientId, tokenFormat, scope string) error {
+	activeContext := cfg.GetActiveContext()
+
+	userTokenClient := uaa.UserTokenClient{ClientId: targetClientId, Scope: requestedScope(scope)}
+	tokenResponse, err := userTokenClient.RequestToken(httpClient, cfg, uaa.TokenFormat(tokenFormat))
+	if _, failed := err.(uaa.RequestError); failed {
+		return errors.New("An error occurred while fetching token.")
+	} else if err != nil {
+		return err
+	}
+	if tokenResponse.RefreshToken == "" {
+		return errors.New("The UAA did not issue a refresh_token for client " + targetClientId + ".")
+	}
+
+	ctx := uaa.UaaContext{
+		GrantType:     uaa.USER_TOKEN,
+		ClientId:      targetClientId,
+		Username:      activeContext.Username,
+		TokenResponse: tokenResponse,
+	}
+	cfg.AddContext(ctx)
+	config.WriteConfig(cfg)
+	log.Infof("Refresh token for client %v successfully fetched and added to context.", utils.Emphasize(targetClientId))
+	log.Infof("Use %v to obtain an access token for this client.", utils.Emphasize("uaa refresh-token -s CLIENT_SECRET"))
+	return nil
+}
+
+var getUserTokenCmd = &cobra.Command{
+	Use:   "get-user-token TARGET_CLIENT_ID",
+	Short: "Obtain a refresh token for another client using the user_token grant type",
+	Long:  help.UserTokenGrant(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(GetUserTokenValidations(cfg, args), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyErrorsWithRetry(GetUserTokenCmd(cfg, GetHttpClient(), log, args[0], tokenFormat, tokenScope), cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getUserTokenCmd)
+	getUserTokenCmd.Annotations = make(map[string]string)
+	getUserTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
+	addScopeFlag(getUserTokenCmd)
+	getUserTokenCmd.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
+}
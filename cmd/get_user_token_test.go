@@ -0,0 +1,101 @@
+package cmd_test
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"net/http"
+)
+
+var _ = Describe("GetUserToken", func() {
+	var userTokenResponseJson = `{
+	  "access_token" : null,
+	  "refresh_token" : "0cb0e2670f7642e9b501a79252f90f02-r",
+	  "token_type" : "bearer",
+	  "expires_in" : 43199,
+	  "scope" : "openid",
+	  "jti" : "0cb0e2670f7642e9b501a79252f90f02"
+	}`
+
+	Describe("with a user context", func() {
+		BeforeEach(func() {
+			c := NewConfigWithServerURL(server.URL())
+			ctx := NewContextWithToken("user-access-token")
+			ctx.ClientId = "cf"
+			ctx.Username = "woodstock"
+			ctx.GrantType = PASSWORD
+			c.AddContext(ctx)
+			config.WriteConfig(c)
+		})
+
+		It("stores the refresh token in a new context", func() {
+			server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+				RespondWith(http.StatusOK, userTokenResponseJson),
+				VerifyHeaderKV("Authorization", "bearer user-access-token"),
+				VerifyFormKV("grant_type", "user_token"),
+				VerifyFormKV("client_id", "provisioner"),
+				VerifyFormKV("token_format", "jwt"),
+			))
+
+			session := runCommand("get-user-token", "provisioner")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Refresh token for client provisioner successfully fetched and added to context."))
+
+			savedConfig := config.ReadConfig()
+			Expect(savedConfig.GetActiveTarget().Contexts).To(HaveLen(2))
+			Expect(savedConfig.GetActiveContext().ClientId).To(Equal("provisioner"))
+			Expect(savedConfig.GetActiveContext().Username).To(Equal("woodstock"))
+			Expect(savedConfig.GetActiveContext().GrantType).To(Equal(USER_TOKEN))
+			Expect(savedConfig.GetActiveContext().RefreshToken).To(Equal("0cb0e2670f7642e9b501a79252f90f02-r"))
+		})
+
+		It("displays an error when the token request fails", func() {
+			server.RouteToHandler("POST", "/oauth/token",
+				RespondWith(http.StatusUnauthorized, `{"error":"invalid_client"}`),
+			)
+
+			session := runCommand("get-user-token", "provisioner")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("An error occurred while fetching token."))
+			Expect(config.ReadConfig().GetActiveContext().ClientId).To(Equal("cf"))
+		})
+
+		It("does not send a revoked access token", func() {
+			c := config.ReadConfig()
+			ctx := c.GetActiveContext()
+			ctx.Revoked = true
+			c.AddContext(ctx)
+			config.WriteConfig(c)
+
+			session := runCommand("get-user-token", "provisioner")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The access token in the active context has been revoked."))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("requires a target client id", func() {
+			session := runCommand("get-user-token")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `target_client_id` must be specified."))
+		})
+	})
+
+	Describe("without a context", func() {
+		It("tells the user to fetch a token", func() {
+			config.WriteConfig(NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("get-user-token", "provisioner")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("You must have a token in your context to perform this command."))
+		})
+	})
+})
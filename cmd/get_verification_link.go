@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+func GetVerificationLinkCmd(um uaa.UserManager, log cli.Logger, username, clientId, redirectUri string) error {
+	// Only the UAA itself verifies the email addresses of its users.
+	user, err := um.GetByUsername(username, "uaa", "id,userName")
+	if err != nil {
+		return err
+	}
+
+	link, err := um.GetVerificationLink(user.ID, clientId, redirectUri)
+	if err != nil {
+		return errors.New(describeError(err))
+	}
+
+	log.Infof("The verification link for user %v is", utils.Emphasize(user.Username))
+	log.Info(link)
+	return nil
+}
+
+func GetVerificationLinkValidations(cfg uaa.Config, args []string, redirectUri string) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("The positional argument USERNAME must be specified.")
+	}
+	if redirectUri == "" {
+		return MissingArgumentError("redirect_uri")
+	}
+	return nil
+}
+
+var getVerificationLinkCmd = &cobra.Command{
+	Use:   "get-verification-link USERNAME",
+	Short: "Create a link with which a user verifies their email address",
+	Long:  help.GetVerificationLink(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(GetVerificationLinkValidations(GetSavedConfig(), args, redirectUri), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		um := uaa.UserManager{GetHttpClient(), cfg}
+		err := GetVerificationLinkCmd(um, log, args[0], redirectClientId, redirectUri)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getVerificationLinkCmd)
+	getVerificationLinkCmd.Annotations = make(map[string]string)
+	getVerificationLinkCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	getVerificationLinkCmd.Flags().StringVarP(&redirectClientId, "client_id", "", "", "the client the user is sent to. Defaults to the client of the active context")
+	getVerificationLinkCmd.Flags().StringVarP(&redirectUri, "redirect_uri", "", "", "where to send the user after verifying. Must be allowed for the client")
+	getVerificationLinkCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain of the user")
+}
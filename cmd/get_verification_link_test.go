@@ -0,0 +1,76 @@
+package cmd_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("GetVerificationLink", func() {
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock%22+and+origin+eq+%22uaa%22&attributes=id%2CuserName"),
+			RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock", ID: "abcdef"})),
+		))
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("get-verification-link", "woodstock", "--redirect_uri", "https://app.example.com")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a username", func() {
+			session := runCommand("get-verification-link", "--redirect_uri", "https://app.example.com")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument USERNAME must be specified."))
+		})
+
+		It("requires a redirect URI", func() {
+			session := runCommand("get-verification-link", "woodstock")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `redirect_uri` must be specified."))
+		})
+	})
+
+	It("prints the verification link of a uaa user", func() {
+		server.RouteToHandler("GET", "/Users/abcdef/verify-link", CombineHandlers(
+			VerifyRequest("GET", "/Users/abcdef/verify-link", "client_id=app&redirect_uri=https%3A%2F%2Fapp.example.com"),
+			RespondWith(http.StatusOK, `{"verify_link": "https://login.example.com/verify_user?code=abc"}`),
+		))
+
+		session := runCommand("get-verification-link", "woodstock", "--client_id", "app", "--redirect_uri", "https://app.example.com")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("The verification link for user woodstock is"))
+		Expect(session.Out).To(Say(`https://login.example.com/verify_user\?code=abc`))
+	})
+
+	It("shows why no link could be created", func() {
+		server.RouteToHandler("GET", "/Users/abcdef/verify-link", RespondWith(http.StatusMethodNotAllowed,
+			`{"error": "user_already_verified", "error_description": "UAA user is already verified."}`))
+
+		session := runCommand("get-verification-link", "woodstock", "--redirect_uri", "https://app.example.com")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("UAA user is already verified."))
+	})
+})
@@ -0,0 +1,506 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+// Import flags
+var (
+	importFormat     string
+	importOnConflict string
+	importReport     string
+)
+
+const (
+	IMPORT_CREATED = "created"
+	IMPORT_UPDATED = "updated"
+	IMPORT_SKIPPED = "skipped"
+	IMPORT_FAILED  = "failed"
+)
+
+// A dry run reports what would have happened, in words which are not taken
+// for a completed import when the report is imported again.
+var dryRunStatuses = map[string]string{
+	IMPORT_CREATED: "would create",
+	IMPORT_UPDATED: "would update",
+	IMPORT_SKIPPED: "would skip",
+	IMPORT_FAILED:  IMPORT_FAILED,
+}
+
+// ImportedUser is one row of an import file. Status and Error are filled in
+// when the row is written to the report, so that the report can be imported
+// again to retry only the rows which failed.
+type ImportedUser struct {
+	Username   string   `json:"username"`
+	GivenName  string   `json:"givenName,omitempty"`
+	FamilyName string   `json:"familyName,omitempty"`
+	Emails     []string `json:"emails,omitempty"`
+	Phones     []string `json:"phones,omitempty"`
+	Origin     string   `json:"origin,omitempty"`
+	Password   string   `json:"password,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+func (iu ImportedUser) imported() bool {
+	return iu.Status == IMPORT_CREATED || iu.Status == IMPORT_UPDATED || iu.Status == IMPORT_SKIPPED
+}
+
+func (iu ImportedUser) csvRecord() []string {
+	return []string{
+		iu.Username,
+		iu.GivenName,
+		iu.FamilyName,
+		strings.Join(iu.Emails, importValueSeparator),
+		strings.Join(iu.Phones, importValueSeparator),
+		iu.Origin,
+		iu.Password,
+		strings.Join(iu.Groups, importValueSeparator),
+		iu.Status,
+		iu.Error,
+	}
+}
+
+func availableImportFormats() []string {
+	return []string{"csv", "jsonl"}
+}
+
+func availableConflictPolicies() []string {
+	return []string{"skip", "update", "fail"}
+}
+
+var importColumns = []string{"username", "givenName", "familyName", "emails", "phones", "origin", "password", "groups", "status", "error"}
+
+// Multiple emails, phones and groups share one CSV column.
+const importValueSeparator = ";"
+
+func importFormatOf(path, format string) string {
+	if format != "" {
+		return format
+	}
+	if strings.ToLower(filepath.Ext(path)) == ".csv" {
+		return "csv"
+	}
+	return "jsonl"
+}
+
+func splitImportValues(column string) []string {
+	values := []string{}
+	for _, value := range strings.Split(column, importValueSeparator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func readImportedUsers(r io.Reader, format string) ([]ImportedUser, error) {
+	if format == "csv" {
+		return readImportedUsersCsv(r)
+	}
+	return readImportedUsersJsonl(r)
+}
+
+func readImportedUsersCsv(r io.Reader) ([]ImportedUser, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return []ImportedUser{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range header {
+		if !utils.Contains(importColumns, column) {
+			return nil, fmt.Errorf(`The column "%v" is unknown. Available columns: %v`, column, utils.StringSliceStringifier(importColumns))
+		}
+	}
+
+	users := []ImportedUser{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return users, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := map[string]string{}
+		for i, column := range header {
+			row[column] = strings.TrimSpace(record[i])
+		}
+		users = append(users, ImportedUser{
+			Username:   row["username"],
+			GivenName:  row["givenName"],
+			FamilyName: row["familyName"],
+			Emails:     splitImportValues(row["emails"]),
+			Phones:     splitImportValues(row["phones"]),
+			Origin:     row["origin"],
+			Password:   row["password"],
+			Groups:     splitImportValues(row["groups"]),
+			Status:     row["status"],
+			Error:      row["error"],
+		})
+	}
+}
+
+func readImportedUsersJsonl(r io.Reader) ([]ImportedUser, error) {
+	users := []ImportedUser{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		user := ImportedUser{}
+		if err := json.Unmarshal(scanner.Bytes(), &user); err != nil {
+			return nil, fmt.Errorf("Line %v could not be parsed: %v", line, err)
+		}
+		users = append(users, user)
+	}
+	return users, scanner.Err()
+}
+
+func writeImportedUsers(w io.Writer, format string, users []ImportedUser) error {
+	if format == "csv" {
+		writer := csv.NewWriter(w)
+		writer.Write(importColumns)
+		for _, user := range users {
+			writer.Write(user.csvRecord())
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, user := range users {
+		if err := encoder.Encode(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func defaultImportReport(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".report" + ext
+}
+
+func describeError(err error) string {
+	if requestErr, ok := err.(uaa.RequestError); ok && requestErr.Description() != "" {
+		return requestErr.Description()
+	}
+	return err.Error()
+}
+
+func isConflict(err error) bool {
+	requestErr, ok := err.(uaa.RequestError)
+	return ok && requestErr.StatusCode == http.StatusConflict
+}
+
+// userImporter creates the users of an import file. It is shared by the
+// workers, so the group lookups it caches are guarded by a mutex.
+type userImporter struct {
+	um         uaa.UserManager
+	gm         uaa.GroupManager
+	onConflict string
+	dryRun     bool
+
+	mutex    sync.Mutex
+	groupIDs map[string]string
+}
+
+func (ui *userImporter) groupID(name string) (string, error) {
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+
+	if id, ok := ui.groupIDs[name]; ok {
+		return id, nil
+	}
+	group, err := ui.gm.GetByName(name, "")
+	if err != nil {
+		return "", err
+	}
+	ui.groupIDs[name] = group.ID
+	return group.ID, nil
+}
+
+// scimFilterString escapes a value for use inside a quoted string of a SCIM
+// filter, so that imported usernames cannot change what the filter matches.
+func scimFilterString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+func (ui *userImporter) findUser(username, origin string) (uaa.ScimUser, bool, error) {
+	filter := fmt.Sprintf(`userName eq "%v" and origin eq "%v"`, scimFilterString(username), scimFilterString(origin))
+	users, err := ui.um.List(filter, "", "", "", 0, 0)
+	if err != nil || len(users.Resources) == 0 {
+		return uaa.ScimUser{}, false, err
+	}
+	return users.Resources[0], true, nil
+}
+
+func (ui *userImporter) toScimUser(row ImportedUser) uaa.ScimUser {
+	user := uaa.ScimUser{
+		Username:     row.Username,
+		Password:     row.Password,
+		Origin:       row.Origin,
+		Emails:       buildEmails(row.Emails),
+		PhoneNumbers: buildPhones(row.Phones),
+	}
+	if row.FamilyName != "" || row.GivenName != "" {
+		user.Name = &uaa.ScimUserName{FamilyName: row.FamilyName, GivenName: row.GivenName}
+	}
+	return user
+}
+
+// resolveConflict handles a row whose user already exists and returns the
+// status of the row together with the id of the existing user.
+func (ui *userImporter) resolveConflict(row ImportedUser) (string, string, error) {
+	if ui.onConflict == "fail" {
+		return IMPORT_FAILED, "", fmt.Errorf("User %v already exists in origin %v.", row.Username, row.Origin)
+	}
+
+	existing, found, err := ui.findUser(row.Username, row.Origin)
+	if err != nil {
+		return IMPORT_FAILED, "", err
+	}
+	if !found {
+		return IMPORT_FAILED, "", fmt.Errorf("User %v was reported to exist but could not be found in origin %v.", row.Username, row.Origin)
+	}
+	if ui.onConflict == "skip" {
+		return IMPORT_SKIPPED, existing.ID, nil
+	}
+
+	changes := ui.toScimUser(row)
+	changes.Username = ""
+	changes.Password = ""
+	changes.Origin = ""
+	if _, err := ui.um.PatchCurrent(existing, changes); err != nil {
+		return IMPORT_FAILED, "", err
+	}
+	return IMPORT_UPDATED, existing.ID, nil
+}
+
+func (ui *userImporter) addToGroups(userID string, groups []string) error {
+	for _, name := range groups {
+		groupID, err := ui.groupID(name)
+		if err != nil {
+			return err
+		}
+		if err := ui.gm.AddMember(groupID, userID); err != nil && !isConflict(err) {
+			return fmt.Errorf("The user could not be added to group %v: %v", name, describeError(err))
+		}
+	}
+	return nil
+}
+
+func (ui *userImporter) dryRunImport(row ImportedUser) (string, error) {
+	_, found, err := ui.findUser(row.Username, row.Origin)
+	if err != nil {
+		return IMPORT_FAILED, err
+	}
+	switch {
+	case !found:
+		return IMPORT_CREATED, nil
+	case ui.onConflict == "fail":
+		return IMPORT_FAILED, fmt.Errorf("User %v already exists in origin %v.", row.Username, row.Origin)
+	case ui.onConflict == "update":
+		return IMPORT_UPDATED, nil
+	default:
+		return IMPORT_SKIPPED, nil
+	}
+}
+
+func (ui *userImporter) importUser(row ImportedUser) ImportedUser {
+	if row.Origin == "" {
+		row.Origin = "uaa"
+	}
+	row.Status, row.Error = "", ""
+
+	var status string
+	var err error
+	switch {
+	case row.Username == "":
+		status, err = IMPORT_FAILED, errors.New("The username is missing.")
+	case len(row.Emails) == 0:
+		status, err = IMPORT_FAILED, errors.New("At least one email address is required.")
+	case ui.dryRun:
+		status, err = ui.dryRunImport(row)
+	default:
+		var userID string
+		created, createErr := ui.um.Create(ui.toScimUser(row))
+		if createErr == nil {
+			status, userID = IMPORT_CREATED, created.ID
+		} else if isConflict(createErr) {
+			status, userID, err = ui.resolveConflict(row)
+		} else {
+			status, err = IMPORT_FAILED, createErr
+		}
+		// Skipped users are left exactly as they are, group memberships
+		// included.
+		if err == nil && status != IMPORT_SKIPPED {
+			if groupErr := ui.addToGroups(userID, row.Groups); groupErr != nil {
+				status, err = IMPORT_FAILED, groupErr
+			}
+		}
+	}
+
+	row.Status = status
+	if err != nil {
+		row.Error = describeError(err)
+	} else {
+		// Passwords are only kept in the report for rows which can be retried.
+		row.Password = ""
+	}
+	return row
+}
+
+func ImportUsersCmd(httpClient *http.Client, config uaa.Config, log cli.Logger, rows []ImportedUser, onConflict string, concurrency int, dryRun bool) ([]ImportedUser, error) {
+	importer := &userImporter{
+		um:         uaa.UserManager{httpClient, config},
+		gm:         uaa.GroupManager{httpClient, config},
+		onConflict: onConflict,
+		dryRun:     dryRun,
+		groupIDs:   map[string]string{},
+	}
+
+	results := make([]ImportedUser, len(rows))
+	forEachConcurrently(len(rows), concurrency, func(index int) {
+		if rows[index].imported() {
+			results[index] = rows[index]
+		} else {
+			results[index] = importer.importUser(rows[index])
+		}
+	})
+
+	previouslyImported := 0
+	for _, row := range rows {
+		if row.imported() {
+			previouslyImported++
+		}
+	}
+
+	counts := map[string]int{}
+	for index, result := range results {
+		if rows[index].imported() {
+			continue
+		}
+		counts[result.Status]++
+		if result.Status == IMPORT_FAILED {
+			log.Errorf("%v: %v", result.Username, result.Error)
+		}
+		if dryRun {
+			results[index].Status = dryRunStatuses[result.Status]
+		}
+	}
+
+	if dryRun {
+		log.Infof("Dry run: %v users would be created, %v updated and %v skipped. %v rows have errors.",
+			counts[IMPORT_CREATED], counts[IMPORT_UPDATED], counts[IMPORT_SKIPPED], counts[IMPORT_FAILED])
+	} else {
+		log.Infof("%v users created, %v updated and %v skipped. %v rows failed.",
+			counts[IMPORT_CREATED], counts[IMPORT_UPDATED], counts[IMPORT_SKIPPED], counts[IMPORT_FAILED])
+	}
+	if previouslyImported > 0 {
+		log.Infof("%v rows were already imported by a previous run and were left alone.", previouslyImported)
+	}
+
+	if counts[IMPORT_FAILED] > 0 {
+		return results, fmt.Errorf("%v of %v users could not be imported.", counts[IMPORT_FAILED], len(rows))
+	}
+	return results, nil
+}
+
+func ImportUsersValidations(cfg uaa.Config, args []string, format, onConflict string, concurrency int) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("The positional argument FILE must be specified.")
+	}
+	if format != "" && !utils.Contains(availableImportFormats(), format) {
+		return fmt.Errorf(`The import format "%v" is unknown. Available formats: %v`, format, utils.StringSliceStringifier(availableImportFormats()))
+	}
+	if !utils.Contains(availableConflictPolicies(), onConflict) {
+		return fmt.Errorf(`The conflict policy "%v" is unknown. Available policies: %v`, onConflict, utils.StringSliceStringifier(availableConflictPolicies()))
+	}
+	return validateConcurrency(concurrency)
+}
+
+var importUsersCmd = &cobra.Command{
+	Use:   "import-users FILE",
+	Short: "Create users from a CSV or JSON lines file",
+	Long:  help.ImportUsers(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(ImportUsersValidations(GetSavedConfig(), args, importFormat, importOnConflict, concurrency), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		format := importFormatOf(args[0], importFormat)
+
+		content, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			log.Error(err.Error())
+			os.Exit(1)
+		}
+		rows, err := readImportedUsers(bytes.NewReader(content), format)
+		if err != nil {
+			log.Errorf("The file %v could not be read: %v", args[0], err)
+			os.Exit(1)
+		}
+
+		results, importErr := ImportUsersCmd(GetHttpClient(), cfg, log, rows, importOnConflict, concurrency, dryRun)
+
+		report := importReport
+		if report == "" {
+			report = defaultImportReport(args[0])
+		}
+		var buffer bytes.Buffer
+		writeImportedUsers(&buffer, format, results)
+		if err := ioutil.WriteFile(report, buffer.Bytes(), 0600); err != nil {
+			log.Error(err.Error())
+			os.Exit(1)
+		}
+		log.Infof("The report was written to %v.", report)
+
+		if importErr != nil {
+			log.Error(importErr.Error())
+			log.Infof("Fix the failed rows in %v and run import-users with it to retry them.", report)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(importUsersCmd)
+	importUsersCmd.Annotations = make(map[string]string)
+	importUsersCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	importUsersCmd.Flags().StringVarP(&importFormat, "format", "", "", fmt.Sprintf("format of the file, one of %v. Guessed from the file extension by default", utils.StringSliceStringifier(availableImportFormats())))
+	importUsersCmd.Flags().StringVarP(&importOnConflict, "on-conflict", "", "skip", fmt.Sprintf("what to do with users which already exist, one of %v", utils.StringSliceStringifier(availableConflictPolicies())))
+	importUsersCmd.Flags().IntVarP(&concurrency, "concurrency", "", 4, "number of users to create at the same time")
+	importUsersCmd.Flags().StringVarP(&importReport, "report", "", "", "file to write the per-row report to. Defaults to FILE with .report before the extension")
+	importUsersCmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "only report what would be done, without changing any users")
+	importUsersCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain in which to import the users")
+}
@@ -0,0 +1,247 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ImportUsers", func() {
+	const conflictJson = `{"error_description": "Username already in use: woodstock", "error": "scim_resource_already_exists"}`
+
+	var dir string
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(ioutil.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	readFile := func(name string) string {
+		content, err := ioutil.ReadFile(filepath.Join(dir, name))
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+
+		dir, _ = ioutil.TempDir("", "uaa-import-users")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("import-users", "users.csv")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a file", func() {
+			session := runCommand("import-users")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument FILE must be specified."))
+		})
+
+		It("rejects unknown formats", func() {
+			session := runCommand("import-users", "users.xml", "--format", "xml")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`The import format "xml" is unknown. Available formats: \[csv, jsonl\]`))
+		})
+
+		It("rejects unknown conflict policies", func() {
+			session := runCommand("import-users", "users.csv", "--on-conflict", "merge")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`The conflict policy "merge" is unknown. Available policies: \[skip, update, fail\]`))
+		})
+
+		It("rejects unknown CSV columns", func() {
+			path := writeFile("users.csv", "username,nickname\nwoodstock,woody\n")
+
+			session := runCommand("import-users", path)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`The column "nickname" is unknown.`))
+		})
+	})
+
+	Describe("from CSV", func() {
+		It("creates the users, adds their memberships and writes a report", func() {
+			path := writeFile("users.csv", "username,givenName,familyName,emails,password,groups\n"+
+				"woodstock,Woodstock,Bird,woodstock@peanuts.com;bird@peanuts.com,secret,uaa.admin\n")
+
+			server.RouteToHandler("POST", "/Users", CombineHandlers(
+				VerifyHeaderKV("Authorization", "bearer access_token"),
+				VerifyJSON(`{
+					"userName": "woodstock",
+					"password": "secret",
+					"origin": "uaa",
+					"name": { "givenName": "Woodstock", "familyName": "Bird" },
+					"emails": [
+						{ "value": "woodstock@peanuts.com", "primary": true },
+						{ "value": "bird@peanuts.com", "primary": false }
+					]
+				}`),
+				RespondWith(http.StatusCreated, fixtures.MarcusUserResponse),
+			))
+			server.RouteToHandler("GET", "/Groups", CombineHandlers(
+				VerifyRequest("GET", "/Groups", "filter=displayName+eq+%22uaa.admin%22"),
+				RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimGroup{ID: "05a0c169-3592-4a45-b109-a16d9246e0ab", DisplayName: "uaa.admin"})),
+			))
+			server.RouteToHandler("POST", "/Groups/05a0c169-3592-4a45-b109-a16d9246e0ab/members", CombineHandlers(
+				VerifyJSON(`{"origin":"uaa","type":"USER","value":"fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70"}`),
+				RespondWith(http.StatusCreated, `{}`),
+			))
+
+			session := runCommand("import-users", path)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("1 users created, 0 updated and 0 skipped. 0 rows failed."))
+			Expect(session.Out).To(Say("The report was written to %s", regexp.QuoteMeta(filepath.Join(dir, "users.report.csv"))))
+			Expect(server.ReceivedRequests()).To(HaveLen(3))
+			Expect(readFile("users.report.csv")).To(Equal(
+				"username,givenName,familyName,emails,phones,origin,password,groups,status,error\n" +
+					"woodstock,Woodstock,Bird,woodstock@peanuts.com;bird@peanuts.com,,uaa,,uaa.admin,created,\n"))
+		})
+
+		It("reports failed rows and retries only those when the report is imported", func() {
+			path := writeFile("users.csv", "username,emails,password\n"+
+				"woodstock,woodstock@peanuts.com,secret\n"+
+				"snoopy,,secret\n")
+
+			server.RouteToHandler("POST", "/Users", RespondWith(http.StatusCreated, fixtures.MarcusUserResponse))
+
+			session := runCommand("import-users", path, "--report", filepath.Join(dir, "report.csv"))
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("snoopy: At least one email address is required."))
+			Expect(session.Err).To(Say("1 of 2 users could not be imported."))
+			Expect(readFile("report.csv")).To(ContainSubstring("woodstock,,,woodstock@peanuts.com,,uaa,,,created,\n"))
+			Expect(readFile("report.csv")).To(ContainSubstring("snoopy,,,,,uaa,secret,,failed,At least one email address is required.\n"))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+			retry := writeFile("retry.csv", "username,emails,password,status\n"+
+				"woodstock,woodstock@peanuts.com,,created\n"+
+				"snoopy,snoopy@peanuts.com,secret,failed\n")
+			server.RouteToHandler("POST", "/Users", CombineHandlers(
+				VerifyJSON(`{"userName": "snoopy", "password": "secret", "origin": "uaa", "emails": [{"value": "snoopy@peanuts.com", "primary": true}]}`),
+				RespondWith(http.StatusCreated, fixtures.MarcusUserResponse),
+			))
+
+			session = runCommand("import-users", retry)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("1 users created, 0 updated and 0 skipped. 0 rows failed."))
+			Expect(session.Out).To(Say("1 rows were already imported by a previous run and were left alone."))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
+	Describe("from JSON lines", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("POST", "/Users", RespondWith(http.StatusConflict, conflictJson))
+			server.RouteToHandler("GET", "/Users", CombineHandlers(
+				VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock%22+and+origin+eq+%22uaa%22"),
+				RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{
+					ID:       "fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70",
+					Username: "woodstock",
+					Meta:     &uaa.ScimMetaInfo{Version: 4},
+				})),
+			))
+		})
+
+		It("skips existing users by default, without changing their memberships", func() {
+			path := writeFile("users.jsonl", `{"username": "woodstock", "emails": ["woodstock@peanuts.com"], "groups": ["uaa.admin"]}`+"\n")
+
+			session := runCommand("import-users", path)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("0 users created, 0 updated and 1 skipped. 0 rows failed."))
+			Expect(readFile("users.report.jsonl")).To(MatchJSON(`{"username": "woodstock", "emails": ["woodstock@peanuts.com"], "groups": ["uaa.admin"], "origin": "uaa", "status": "skipped"}`))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("escapes quotes and backslashes in the filter which finds existing users", func() {
+			path := writeFile("users.jsonl", `{"username": "wood\"stock\\", "emails": ["woodstock@peanuts.com"]}`+"\n")
+			server.RouteToHandler("GET", "/Users", CombineHandlers(
+				func(w http.ResponseWriter, req *http.Request) {
+					Expect(req.URL.Query().Get("filter")).To(Equal(`userName eq "wood\"stock\\" and origin eq "uaa"`))
+				},
+				RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{ID: "fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", Username: `wood"stock\`})),
+			))
+
+			session := runCommand("import-users", path)
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("0 users created, 0 updated and 1 skipped. 0 rows failed."))
+		})
+
+		It("updates existing users with --on-conflict update", func() {
+			path := writeFile("users.jsonl", `{"username": "woodstock", "familyName": "Bird", "emails": ["woodstock@peanuts.com"]}`+"\n")
+			server.RouteToHandler("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", CombineHandlers(
+				VerifyHeaderKV("If-Match", "4"),
+				VerifyJSON(`{"name": {"familyName": "Bird"}, "emails": [{"value": "woodstock@peanuts.com", "primary": true}]}`),
+				RespondWith(http.StatusOK, fixtures.MarcusUserResponse),
+			))
+
+			session := runCommand("import-users", path, "--on-conflict", "update")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("0 users created, 1 updated and 0 skipped. 0 rows failed."))
+		})
+
+		It("fails existing users with --on-conflict fail", func() {
+			path := writeFile("users.jsonl", `{"username": "woodstock", "emails": ["woodstock@peanuts.com"]}`+"\n")
+
+			session := runCommand("import-users", path, "--on-conflict", "fail")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("woodstock: User woodstock already exists in origin uaa."))
+		})
+
+		It("changes nothing with --dry-run", func() {
+			path := writeFile("users.jsonl", `{"username": "woodstock", "emails": ["woodstock@peanuts.com"]}`+"\n"+
+				`{"username": "snoopy", "emails": ["snoopy@peanuts.com"]}`+"\n")
+			server.RouteToHandler("GET", "/Users", func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Query().Get("filter") == `userName eq "woodstock" and origin eq "uaa"` {
+					w.Write([]byte(fixtures.PaginatedResponse(uaa.ScimUser{ID: "fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", Username: "woodstock"})))
+					return
+				}
+				w.Write([]byte(fixtures.PaginatedResponse()))
+			})
+
+			session := runCommand("import-users", path, "--dry-run", "--on-conflict", "update")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Dry run: 1 users would be created, 1 updated and 0 skipped. 0 rows have errors."))
+			for _, req := range server.ReceivedRequests() {
+				Expect(req.Method).To(Equal("GET"))
+			}
+			Expect(readFile("users.report.jsonl")).To(ContainSubstring(`"status":"would update"`))
+			Expect(readFile("users.report.jsonl")).To(ContainSubstring(`"status":"would create"`))
+		})
+	})
+})
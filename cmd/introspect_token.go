@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"errors"
+	"fmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Introspection flags
+var (
+	introspectClient string
+	outputFormat     string
+)
+
+func availableOutputFormats() []string {
+	return []string{"json", "table"}
+}
+
+func formatTimestamp(seconds int64) string {
+	if seconds == 0 {
+		return ""
+	}
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+}
+
+func printIntrospectionTable(introspection uaa.TokenIntrospection) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Field", "Value"})
+	table.Append([]string{"Active", strconv.FormatBool(introspection.Active)})
+	table.Append([]string{"Scopes", strings.Join(introspection.Scope, " ")})
+	table.Append([]string{"Audience", strings.Join(introspection.Audience, " ")})
+	table.Append([]string{"Client", introspection.ClientId})
+	table.Append([]string{"User", introspection.Username})
+	table.Append([]string{"Issued At", formatTimestamp(introspection.IssuedAt)})
+	table.Append([]string{"Expires At", formatTimestamp(introspection.ExpiresAt)})
+	table.Render()
+}
+
+func IntrospectTokenCmd(cfg uaa.Config, httpClient *http.Client, printer cli.Printer, clientId, clientSecret, token, output string) error {
+	activeContext := cfg.GetActiveContext()
+	if token == "" {
+		token = activeContext.AccessToken
+	}
+	if clientId == "" {
+		clientId = activeContext.ClientId
+	}
+
+	clientAuth, err := buildClientAuthentication(clientId, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	ic := uaa.IntrospectionClient{ClientId: clientId, ClientSecret: clientSecret, ClientAuth: clientAuth}
+	introspection, err := ic.Introspect(httpClient, cfg, token)
+	if err != nil {
+		return errors.New("An error occurred while introspecting token.")
+	}
+
+	if output == "table" {
+		printIntrospectionTable(introspection)
+		return nil
+	}
+	return printer.Print(introspection)
+}
+
+func IntrospectTokenValidations(cfg uaa.Config, args []string, clientSecret string) error {
+	if err := EnsureTargetInConfig(cfg); err != nil {
+		return err
+	}
+	activeContext := cfg.GetActiveContext()
+	if len(args) == 0 && activeContext.AccessToken == "" {
+		return MissingArgumentWithExplanationError("token", "There is no access_token in the active context to introspect.")
+	}
+	if introspectClient == "" && activeContext.ClientId == "" {
+		return MissingArgumentWithExplanationError("client", "There is no client_id in the active context.")
+	}
+	if !utils.Contains(availableOutputFormats(), outputFormat) {
+		return fmt.Errorf(`The output format "%v" is unknown. Available formats: %v`, outputFormat, utils.StringSliceStringifier(availableOutputFormats()))
+	}
+	return validateClientAuthentication(clientSecret)
+}
+
+var introspectTokenCmd = &cobra.Command{
+	Use:   "introspect-token [TOKEN] --client CLIENT_ID -s CLIENT_SECRET",
+	Short: "Check whether a token is valid and view the scopes it carries",
+	Long:  help.IntrospectToken(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(IntrospectTokenValidations(cfg, args, clientSecret), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		var token string
+		if len(args) > 0 {
+			token = args[0]
+		}
+		err := IntrospectTokenCmd(cfg, GetHttpClient(), cli.NewJsonPrinter(log), introspectClient, clientSecret, token, outputFormat)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(introspectTokenCmd)
+	introspectTokenCmd.Annotations = make(map[string]string)
+	introspectTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
+
+	introspectTokenCmd.Flags().StringVarP(&introspectClient, "client", "", "", "id of a client with the uaa.resource authority (defaults to the client of the active context)")
+	introspectTokenCmd.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "client secret")
+	// The introspection endpoints expect client credentials in an HTTP Basic
+	// header rather than in the form body.
+	addClientAuthenticationFlags(introspectTokenCmd, uaa.CLIENT_SECRET_BASIC)
+	introspectTokenCmd.Flags().StringVarP(&outputFormat, "output", "", "json", "output format, one of "+utils.StringSliceStringifier(availableOutputFormats()))
+}
@@ -0,0 +1,133 @@
+package cmd_test
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"net/http"
+)
+
+var _ = Describe("IntrospectToken", func() {
+	const introspectResponse = `{
+	  "active" : true,
+	  "scope" : "openid scim.read",
+	  "aud" : [ "scim", "openid" ],
+	  "client_id" : "cf",
+	  "user_name" : "woodstock",
+	  "exp" : 1500000000
+	}`
+
+	BeforeEach(func() {
+		c := uaa.NewConfigWithServerURL(server.URL())
+		ctx := uaa.NewContextWithToken("access_token")
+		ctx.ClientId = "cf"
+		c.AddContext(ctx)
+		config.WriteConfig(c)
+	})
+
+	It("introspects the active access token and prints JSON", func() {
+		server.RouteToHandler("POST", "/introspect", CombineHandlers(
+			VerifyBasicAuth("resource-server", "secret"),
+			VerifyFormKV("token", "access_token"),
+			RespondWith(http.StatusOK, introspectResponse),
+		))
+
+		session := runCommand("introspect-token", "--client", "resource-server", "-s", "secret")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(`{
+		  "active": true,
+		  "scope": ["openid", "scim.read"],
+		  "aud": ["scim", "openid"],
+		  "client_id": "cf",
+		  "user_name": "woodstock",
+		  "exp": 1500000000
+		}`))
+	})
+
+	It("introspects a given token and prints a table", func() {
+		server.RouteToHandler("POST", "/introspect", CombineHandlers(
+			VerifyFormKV("token", "other-token"),
+			RespondWith(http.StatusOK, introspectResponse),
+		))
+
+		session := runCommand("introspect-token", "other-token", "-s", "cfsecret", "--output", "table")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say(`Active\s+\|\s+true`))
+		Expect(session.Out).To(Say(`Scopes\s+\|\s+openid scim.read`))
+		Expect(session.Out).To(Say(`Client\s+\|\s+cf`))
+		Expect(session.Out).To(Say(`User\s+\|\s+woodstock`))
+		Expect(session.Out).To(Say(`Expires At\s+\|\s+2017-07-14T02:40:00Z`))
+	})
+
+	It("falls back to /check_token", func() {
+		server.RouteToHandler("POST", "/introspect", RespondWith(http.StatusNotFound, ""))
+		server.RouteToHandler("POST", "/check_token", CombineHandlers(
+			VerifyBasicAuth("cf", "cfsecret"),
+			RespondWith(http.StatusOK, `{"scope":["openid"],"client_id":"cf"}`),
+		))
+
+		session := runCommand("introspect-token", "-s", "cfsecret")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(`{"active": true, "scope": ["openid"], "client_id": "cf"}`))
+	})
+
+	It("can send client credentials in the form body", func() {
+		server.RouteToHandler("POST", "/introspect", CombineHandlers(
+			VerifyFormKV("client_id", "cf"),
+			VerifyFormKV("client_secret", "cfsecret"),
+			RespondWith(http.StatusOK, introspectResponse),
+		))
+
+		session := runCommand("introspect-token", "-s", "cfsecret", "--auth-method", "client_secret_post")
+
+		Eventually(session).Should(Exit(0))
+	})
+
+	It("documents client_secret_basic as its default authentication method", func() {
+		session := runCommand("introspect-token", "-h")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say(`--auth-method string\s+.*\(default "client_secret_basic"\)`))
+	})
+
+	It("displays an error when introspection fails", func() {
+		server.RouteToHandler("POST", "/introspect", RespondWith(http.StatusUnauthorized, `{"error":"unauthorized"}`))
+
+		session := runCommand("introspect-token", "-s", "wrong")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("An error occurred while introspecting token."))
+	})
+
+	Describe("validations", func() {
+		It("requires a client secret", func() {
+			session := runCommand("introspect-token")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `client_secret` must be specified."))
+		})
+
+		It("rejects unknown output formats", func() {
+			session := runCommand("introspect-token", "-s", "cfsecret", "--output", "yaml")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`The output format "yaml" is unknown.`))
+		})
+
+		It("requires a token when there is no context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("introspect-token", "--client", "cf", "-s", "cfsecret")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `token` must be specified."))
+		})
+	})
+})
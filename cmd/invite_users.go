@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// readEmails returns the addresses in a file with one per line. Blank lines
+// and lines starting with # are skipped.
+func readEmails(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	emails := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			emails = append(emails, line)
+		}
+	}
+	return emails, scanner.Err()
+}
+
+func printInvitationTables(log cli.Logger, result uaa.InvitationResult) {
+	if len(result.NewInvites) > 0 {
+		log.Infof("Invited %v users:", len(result.NewInvites))
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Email", "User ID", "Invitation Link"})
+		table.SetAutoWrapText(false)
+		for _, invite := range result.NewInvites {
+			table.Append([]string{invite.Email, invite.UserId, invite.InviteLink})
+		}
+		table.Render()
+	}
+	if len(result.FailedInvites) > 0 {
+		log.Infof("Could not invite %v users:", len(result.FailedInvites))
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Email", "Error"})
+		table.SetAutoWrapText(false)
+		for _, invite := range result.FailedInvites {
+			table.Append([]string{invite.Email, invite.ErrorMessage})
+		}
+		table.Render()
+	}
+}
+
+func InviteUsersCmd(um uaa.UserManager, printer cli.Printer, log cli.Logger, emails []string, fromFile, clientId, redirectUri, output string) error {
+	if fromFile != "" {
+		fileEmails, err := readEmails(fromFile)
+		if err != nil {
+			return err
+		}
+		emails = append(emails, fileEmails...)
+	}
+	if len(emails) == 0 {
+		return errors.New("No email addresses to invite.")
+	}
+
+	result, err := um.Invite(emails, clientId, redirectUri)
+	if err != nil {
+		return errors.New(describeError(err))
+	}
+
+	if output == "table" {
+		printInvitationTables(log, result)
+	} else if err := printer.Print(result); err != nil {
+		return err
+	}
+
+	if len(result.FailedInvites) > 0 {
+		return fmt.Errorf("%v of %v users could not be invited.", len(result.FailedInvites), len(emails))
+	}
+	return nil
+}
+
+func InviteUsersValidations(cfg uaa.Config, args []string, fromFile, redirectUri, output string) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 && fromFile == "" {
+		return errors.New("Give the email addresses to invite as arguments or with --from-file.")
+	}
+	if redirectUri == "" {
+		return MissingArgumentError("redirect_uri")
+	}
+	if !utils.Contains(availableOutputFormats(), output) {
+		return fmt.Errorf(`The output format "%v" is unknown. Available formats: %v`, output, utils.StringSliceStringifier(availableOutputFormats()))
+	}
+	return nil
+}
+
+var inviteUsersCmd = &cobra.Command{
+	Use:   "invite-users EMAIL...",
+	Short: "Invite users by email address",
+	Long:  help.InviteUsers(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(InviteUsersValidations(GetSavedConfig(), args, fromFile, redirectUri, outputFormat), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		um := uaa.UserManager{GetHttpClient(), cfg}
+		err := InviteUsersCmd(um, cli.NewJsonPrinter(log), log, args, fromFile, redirectClientId, redirectUri, outputFormat)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(inviteUsersCmd)
+	inviteUsersCmd.Annotations = make(map[string]string)
+	inviteUsersCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	inviteUsersCmd.Flags().StringVarP(&redirectClientId, "client_id", "", "", "the client the invited users are sent to. Defaults to the client of the active context")
+	inviteUsersCmd.Flags().StringVarP(&redirectUri, "redirect_uri", "", "", "where to send users after they accept the invitation. Must be allowed for the client")
+	inviteUsersCmd.Flags().StringVarP(&fromFile, "from-file", "", "", "a file with one email address per line to invite as well")
+	inviteUsersCmd.Flags().StringVarP(&outputFormat, "output", "", "json", "output format, one of "+utils.StringSliceStringifier(availableOutputFormats()))
+	inviteUsersCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain in which to invite the users")
+}
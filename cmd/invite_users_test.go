@@ -0,0 +1,122 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("InviteUsers", func() {
+	const invitations = `{
+		"new_invites": [{"email": "snoopy@peanuts.com", "userId": "abcdef", "origin": "uaa", "success": true, "inviteLink": "https://login.example.com/invitations/accept?code=abc"}],
+		"failed_invites": []
+	}`
+
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("invite-users", "snoopy@peanuts.com", "--redirect_uri", "https://app.example.com")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires email addresses", func() {
+			session := runCommand("invite-users", "--redirect_uri", "https://app.example.com")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Give the email addresses to invite as arguments or with --from-file."))
+		})
+
+		It("requires a redirect URI", func() {
+			session := runCommand("invite-users", "snoopy@peanuts.com")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `redirect_uri` must be specified."))
+		})
+
+		It("rejects unknown output formats", func() {
+			session := runCommand("invite-users", "snoopy@peanuts.com", "--redirect_uri", "https://app.example.com", "--output", "xml")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`The output format "xml" is unknown.`))
+		})
+	})
+
+	It("invites the users and prints the results as JSON", func() {
+		server.RouteToHandler("POST", "/invite_users", CombineHandlers(
+			VerifyRequest("POST", "/invite_users", "client_id=app&redirect_uri=https%3A%2F%2Fapp.example.com"),
+			VerifyHeaderKV("Authorization", "bearer access_token"),
+			VerifyJSON(`{"emails": ["snoopy@peanuts.com"]}`),
+			RespondWith(http.StatusOK, invitations),
+		))
+
+		session := runCommand("invite-users", "snoopy@peanuts.com", "--client_id", "app", "--redirect_uri", "https://app.example.com")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out.Contents()).To(MatchJSON(`{
+			"new_invites": [{"email": "snoopy@peanuts.com", "userId": "abcdef", "origin": "uaa", "success": true, "inviteLink": "https://login.example.com/invitations/accept?code=abc"}],
+			"failed_invites": []
+		}`))
+	})
+
+	Describe("--from-file", func() {
+		var path string
+
+		BeforeEach(func() {
+			file, _ := ioutil.TempFile("", "uaa-invite-users")
+			path = file.Name()
+			file.WriteString("# partners\nwoodstock@peanuts.com\n\n  lucy@peanuts.com  \n")
+			file.Close()
+		})
+
+		AfterEach(func() {
+			os.Remove(path)
+		})
+
+		It("invites the addresses in the file after the arguments", func() {
+			server.RouteToHandler("POST", "/invite_users", CombineHandlers(
+				VerifyJSON(`{"emails": ["snoopy@peanuts.com", "woodstock@peanuts.com", "lucy@peanuts.com"]}`),
+				RespondWith(http.StatusOK, invitations),
+			))
+
+			session := runCommand("invite-users", "snoopy@peanuts.com", "--from-file", path, "--redirect_uri", "https://app.example.com")
+
+			Eventually(session).Should(Exit(0))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	It("prints tables of the invited and failed users and fails", func() {
+		server.RouteToHandler("POST", "/invite_users", RespondWith(http.StatusOK, `{
+			"new_invites": [{"email": "snoopy@peanuts.com", "userId": "abcdef", "success": true, "inviteLink": "https://login.example.com/invitations/accept?code=abc"}],
+			"failed_invites": [{"email": "woodstock@peanuts.com", "success": false, "errorCode": "user.ambiguous", "errorMessage": "User email is ambiguous"}]
+		}`))
+
+		session := runCommand("invite-users", "snoopy@peanuts.com", "woodstock@peanuts.com", "--redirect_uri", "https://app.example.com", "--output", "table")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Out).To(Say("Invited 1 users:"))
+		Expect(session.Out).To(Say(`EMAIL\s+\|\s+USER ID\s+\|\s+INVITATION LINK`))
+		Expect(session.Out).To(Say(`snoopy@peanuts.com\s+\|\s+abcdef\s+\|\s+https://login.example.com/invitations/accept\?code=abc`))
+		Expect(session.Out).To(Say("Could not invite 1 users:"))
+		Expect(session.Out).To(Say(`woodstock@peanuts.com\s+\|\s+User email is ambiguous`))
+		Expect(session.Err).To(Say("1 of 2 users could not be invited."))
+	})
+})
@@ -21,6 +21,22 @@ func ListGroupsCmd(gm uaa.GroupManager, printer cli.Printer, filter, sortBy, sor
 	return printer.Print(group)
 }
 
+func ListAllGroupsCmd(gm uaa.GroupManager, printer cli.Printer, filter, sortBy, sortOrder, attributes string, count int) error {
+	options := uaa.ListOptions{Filter: filter, SortBy: sortBy, SortOrder: uaa.ScimSortOrder(sortOrder), Attributes: attributes, PageSize: count}
+	list := uaa.PaginatedGroupList{Resources: []uaa.ScimGroup{}, StartIndex: 1}
+	groups := gm.ListAll(options)
+	for groups.Next() {
+		list.Resources = append(list.Resources, groups.Group())
+	}
+	if groups.Err() != nil {
+		return groups.Err()
+	}
+
+	list.ItemsPerPage = int32(len(list.Resources))
+	list.TotalResults = int32(len(list.Resources))
+	return printer.Print(list)
+}
+
 var listGroupsCmd = &cobra.Command{
 	Use:     "list-groups",
 	Aliases: []string{"groups", "get-groups", "search-groups"},
@@ -31,7 +47,12 @@ var listGroupsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := GetSavedConfig()
 		gm := uaa.GroupManager{GetHttpClient(), cfg}
-		err := ListGroupsCmd(gm, cli.NewJsonPrinter(log), filter, sortBy, sortOrder, attributes, startIndex, count)
+		var err error
+		if listAll {
+			err = ListAllGroupsCmd(gm, cli.NewJsonPrinter(log), filter, sortBy, sortOrder, attributes, count)
+		} else {
+			err = ListGroupsCmd(gm, cli.NewJsonPrinter(log), filter, sortBy, sortOrder, attributes, startIndex, count)
+		}
 		NotifyErrorsWithRetry(err, cfg, log)
 	},
 }
@@ -47,5 +68,6 @@ func init() {
 	listGroupsCmd.Flags().StringVarP(&attributes, "attributes", "a", "", `include only these comma-separated attributes to improve query performance`)
 	listGroupsCmd.Flags().IntVarP(&startIndex, "startIndex", "s", 1, `starting index of paginated results`)
 	listGroupsCmd.Flags().IntVarP(&count, "count", "c", 100, `maximum number of results to return`)
+	listGroupsCmd.Flags().BoolVarP(&listAll, "all", "", false, `return the results of every page, fetching --count results at a time`)
 	listGroupsCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain from which to list the groups")
 }
@@ -40,6 +40,31 @@ var _ = Describe("ListGroups", func() {
 		Eventually(session).Should(Exit(0))
 	})
 
+	It("returns the results of every page with --all", func() {
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest("GET", "/Groups", "count=1&startIndex=1"),
+				RespondWith(http.StatusOK, `{"resources": [{"displayName": "uaa.admin"}], "totalResults": 2}`),
+			),
+			CombineHandlers(
+				VerifyRequest("GET", "/Groups", "count=1&startIndex=2"),
+				RespondWith(http.StatusOK, `{"resources": [{"displayName": "openid"}], "totalResults": 2}`),
+			),
+		)
+
+		session := runCommand("list-groups", "--count", "1", "--all")
+
+		Eventually(session).Should(Exit(0))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+		Expect(session.Out.Contents()).To(MatchJSON(`{
+			"resources": [{"displayName": "uaa.admin"}, {"displayName": "openid"}],
+			"startIndex": 1,
+			"itemsPerPage": 2,
+			"totalResults": 2,
+			"schemas": null
+		}`))
+	})
+
 	It("understands the --zone flag", func() {
 		server.RouteToHandler("GET", "/Groups", CombineHandlers(
 			VerifyRequest("GET", "/Groups", "filter=verified+eq+false&attributes=id%2CdisplayName&sortBy=displayName&sortOrder=descending&count=50&startIndex=100"),
@@ -1,12 +1,25 @@
 package cmd
 
 import (
+	"fmt"
+
 	"code.cloudfoundry.org/uaa-cli/cli"
 	"code.cloudfoundry.org/uaa-cli/help"
 	"code.cloudfoundry.org/uaa-cli/uaa"
 	"github.com/spf13/cobra"
 )
 
+var unverifiedOnly bool
+
+// unverifiedFilter narrows a SCIM filter to users whose email address has not
+// been verified.
+func unverifiedFilter(filter string) string {
+	if filter == "" {
+		return "verified eq false"
+	}
+	return fmt.Sprintf("(%v) and verified eq false", filter)
+}
+
 func ListUserValidations(cfg uaa.Config) error {
 	if err := EnsureContextInConfig(cfg); err != nil {
 		return err
@@ -22,6 +35,22 @@ func ListUsersCmd(um uaa.UserManager, printer cli.Printer, filter, sortBy, sortO
 	return printer.Print(user)
 }
 
+func ListAllUsersCmd(um uaa.UserManager, printer cli.Printer, filter, sortBy, sortOrder, attributes string, count int) error {
+	options := uaa.ListOptions{Filter: filter, SortBy: sortBy, SortOrder: uaa.ScimSortOrder(sortOrder), Attributes: attributes, PageSize: count}
+	list := uaa.PaginatedUserList{Resources: []uaa.ScimUser{}, StartIndex: 1}
+	users := um.ListAll(options)
+	for users.Next() {
+		list.Resources = append(list.Resources, users.User())
+	}
+	if users.Err() != nil {
+		return users.Err()
+	}
+
+	list.ItemsPerPage = int32(len(list.Resources))
+	list.TotalResults = int32(len(list.Resources))
+	return printer.Print(list)
+}
+
 var listUsersCmd = &cobra.Command{
 	Use:     "list-users",
 	Aliases: []string{"users", "get-users", "search-users"},
@@ -33,7 +62,16 @@ var listUsersCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := GetSavedConfig()
 		um := uaa.UserManager{GetHttpClient(), cfg}
-		err := ListUsersCmd(um, cli.NewJsonPrinter(log), filter, sortBy, sortOrder, attributes, startIndex, count)
+		filter := filter
+		if unverifiedOnly {
+			filter = unverifiedFilter(filter)
+		}
+		var err error
+		if listAll {
+			err = ListAllUsersCmd(um, cli.NewJsonPrinter(log), filter, sortBy, sortOrder, attributes, count)
+		} else {
+			err = ListUsersCmd(um, cli.NewJsonPrinter(log), filter, sortBy, sortOrder, attributes, startIndex, count)
+		}
 		NotifyErrorsWithRetry(err, cfg, log)
 	},
 }
@@ -49,5 +87,7 @@ func init() {
 	listUsersCmd.Flags().StringVarP(&attributes, "attributes", "a", "", `include only these comma-separated user attributes to improve query performance`)
 	listUsersCmd.Flags().IntVarP(&startIndex, "startIndex", "s", 1, `starting index of paginated results`)
 	listUsersCmd.Flags().IntVarP(&count, "count", "c", 100, `maximum number of results to return`)
+	listUsersCmd.Flags().BoolVarP(&unverifiedOnly, "unverified", "", false, `list only users whose email address is not verified, in addition to --filter`)
+	listUsersCmd.Flags().BoolVarP(&listAll, "all", "", false, `return the results of every page, fetching --count results at a time`)
 	listUsersCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain in which to list the users")
 }
@@ -40,6 +40,53 @@ var _ = Describe("ListUsers", func() {
 		Eventually(session).Should(Exit(0))
 	})
 
+	It("lists only unverified users with --unverified", func() {
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=verified+eq+false&startIndex=1&count=100"),
+			RespondWith(http.StatusOK, userListResponse),
+		))
+
+		session := runCommand("list-users", "--unverified")
+
+		Eventually(session).Should(Exit(0))
+	})
+
+	It("combines --unverified with --filter", func() {
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=%28origin+eq+%22uaa%22%29+and+verified+eq+false&startIndex=1&count=100"),
+			RespondWith(http.StatusOK, userListResponse),
+		))
+
+		session := runCommand("list-users", "--unverified", "--filter", `origin eq "uaa"`)
+
+		Eventually(session).Should(Exit(0))
+	})
+
+	It("returns the results of every page with --all", func() {
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest("GET", "/Users", "filter=verified+eq+false&sortBy=userName&count=1&startIndex=1"),
+				RespondWith(http.StatusOK, `{"resources": [{"userName": "marcus"}], "startIndex": 1, "itemsPerPage": 1, "totalResults": 2}`),
+			),
+			CombineHandlers(
+				VerifyRequest("GET", "/Users", "filter=verified+eq+false&sortBy=userName&count=1&startIndex=2"),
+				RespondWith(http.StatusOK, `{"resources": [{"userName": "drseuss"}], "startIndex": 2, "itemsPerPage": 1, "totalResults": 2}`),
+			),
+		)
+
+		session := runCommand("list-users", "--filter", "verified eq false", "--sortBy", "userName", "--count", "1", "--all")
+
+		Eventually(session).Should(Exit(0))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+		Expect(session.Out.Contents()).To(MatchJSON(`{
+			"resources": [{"userName": "marcus"}, {"userName": "drseuss"}],
+			"startIndex": 1,
+			"itemsPerPage": 2,
+			"totalResults": 2,
+			"schemas": null
+		}`))
+	})
+
 	It("understands the --zone flag", func() {
 		server.RouteToHandler("GET", "/Users", CombineHandlers(
 			VerifyRequest("GET", "/Users", "filter=verified+eq+false&attributes=id%2CuserName&sortBy=userName&sortOrder=descending&count=50&startIndex=100"),
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"errors"
+	"fmt"
+	"github.com/skratchdot/open-golang/open"
+	"github.com/spf13/cobra"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Login flags
+var (
+	loginClient      string
+	loginSso         bool
+	loginSsoPasscode bool
+)
+
+// A prompt declared in /info is a pair of its input type and its label, e.g.
+// ["password", "Password"].
+func promptFor(name string, prompts map[string][]string) (string, error) {
+	label := strings.Title(name)
+	inputType := "text"
+	if name == "password" {
+		inputType = "password"
+	}
+	if prompt, ok := prompts[name]; ok && len(prompt) == 2 {
+		inputType, label = prompt[0], prompt[1]
+	}
+
+	if inputType == "password" {
+		return promptForSecret(label)
+	}
+	return cli.InteractivePrompt{Prompt: label}.Get()
+}
+
+func externalIdps(info uaa.UaaInfo) []string {
+	names := []string{}
+	for name := range info.IdpDefinitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loginWithPasscode follows the cf CLI: the user obtains a one-time passcode
+// from the login server in a browser, which is then used in place of a
+// username and password.
+func loginWithPasscode(cfg uaa.Config, httpClient *http.Client, log cli.Logger, info uaa.UaaInfo, clientId, clientSecret string) error {
+	log.Infof("Get a one-time passcode at %v", utils.Emphasize(info.PasscodeUrl(cfg.GetActiveTarget().BaseUrl)))
+	code, err := promptFor("passcode", info.Prompts)
+	if err != nil {
+		return err
+	}
+	if code == "" {
+		return errors.New("A passcode is required to log in with a one-time passcode.")
+	}
+
+	return GetPasswordTokenCmd(cfg, httpClient, clientId, clientSecret, "", "", code, "", tokenFormat, tokenScope, origin)
+}
+
+// loginWithBrowser runs the authorization_code grant with a local callback
+// server on port, as get-authcode-token does.
+func loginWithBrowser(cfg uaa.Config, httpClient *http.Client, log *cli.Logger, clientId, clientSecret string, port int) error {
+	if port == 0 {
+		return MissingArgumentWithExplanationError("port", `The port number must correspond to a localhost redirect_uri specified in the client configuration.`)
+	}
+
+	done := make(chan bool)
+	authcodeImp := cli.NewAuthcodeClientImpersonator(httpClient, cfg, clientId, clientSecret, tokenFormat, requestedScope(tokenScope), port, *log, open.Run)
+	authcodeImp.Origin = origin
+	go AuthcodeTokenCommandRun(done, clientId, authcodeImp, log)
+	<-done
+	return nil
+}
+
+// Login methods offered when the UAA has external identity providers
+const (
+	LOGIN_PASSWORD = "1"
+	LOGIN_BROWSER  = "2"
+	LOGIN_PASSCODE = "3"
+)
+
+func chooseLoginMethod(log cli.Logger, idps []string) (string, error) {
+	log.Infof("This UAA also authenticates users with %v. How do you want to log in?", strings.Join(idps, ", "))
+	log.Info("  1. with a username and password")
+	log.Info("  2. with a browser (requires --port)")
+	log.Info("  3. with a one-time passcode")
+	choice, err := cli.InteractivePrompt{Prompt: "Login method [1]"}.Get()
+	if err != nil {
+		return "", err
+	}
+	switch choice {
+	case "", LOGIN_PASSWORD:
+		return LOGIN_PASSWORD, nil
+	case LOGIN_BROWSER, LOGIN_PASSCODE:
+		return choice, nil
+	}
+	return "", fmt.Errorf("%v is not a login method. Enter 1, 2 or 3.", choice)
+}
+
+// passwordPromptNames orders the prompts declared in /info for the password
+// grant: username and password first, then any others by name. The passcode
+// prompt is left out, since a passcode replaces the username and password.
+func passwordPromptNames(prompts map[string][]string) []string {
+	names := []string{"username", "password"}
+	others := []string{}
+	for name := range prompts {
+		if name != "username" && name != "password" && name != "passcode" {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	return append(names, others...)
+}
+
+func LoginCmd(cfg uaa.Config, httpClient *http.Client, log cli.Logger, clientId, clientSecret, username, password string, ssoPasscode bool, port int) error {
+	info, err := uaa.Info(httpClient, cfg)
+	if err != nil {
+		return errors.New("An error occurred while fetching the login prompts of the target.")
+	}
+
+	if ssoPasscode {
+		return loginWithPasscode(cfg, httpClient, log, info, clientId, clientSecret)
+	}
+
+	if idps := externalIdps(info); len(idps) > 0 && username == "" {
+		method, err := chooseLoginMethod(log, idps)
+		if err != nil {
+			return err
+		}
+		switch method {
+		case LOGIN_BROWSER:
+			return loginWithBrowser(cfg, httpClient, &log, clientId, clientSecret, port)
+		case LOGIN_PASSCODE:
+			return loginWithPasscode(cfg, httpClient, log, info, clientId, clientSecret)
+		}
+	}
+
+	answers := map[string]string{"username": username, "password": password}
+	for _, name := range passwordPromptNames(info.Prompts) {
+		if answers[name] != "" {
+			continue
+		}
+		answers[name], err = promptFor(name, info.Prompts)
+		if err != nil {
+			return err
+		}
+	}
+	username, password = answers["username"], answers["password"]
+	if username == "" || password == "" {
+		return errors.New("A username and password are required to log in.")
+	}
+	delete(answers, "username")
+	delete(answers, "password")
+
+	clientAuth, err := buildClientAuthentication(clientId, clientSecret)
+	if err != nil {
+		return err
+	}
+	ccClient := uaa.ResourceOwnerPasswordClient{
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		ClientAuth:   clientAuth,
+		Username:     username,
+		Password:     password,
+		MfaCode:      mfaCode,
+		Scope:        requestedScope(tokenScope),
+		Origin:       origin,
+		Prompts:      answers,
+	}
+	return fetchPasswordToken(cfg, httpClient, ccClient, tokenFormat)
+}
+
+func LoginValidations(cfg uaa.Config, sso, ssoPasscode bool, port int) error {
+	if err := EnsureTargetInConfig(cfg); err != nil {
+		return err
+	}
+	if sso && ssoPasscode {
+		return errors.New("Use either --sso or --sso-passcode, not both.")
+	}
+	if sso && port == 0 {
+		return MissingArgumentWithExplanationError("port", `The port number must correspond to a localhost redirect_uri specified in the client configuration.`)
+	}
+	return validateTokenFormatError(tokenFormat)
+}
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in as a user, prompting for the credentials the UAA asks for",
+	Long:  help.Login(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(LoginValidations(cfg, loginSso, loginSsoPasscode, port), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		if loginSso {
+			NotifyErrorsWithRetry(loginWithBrowser(cfg, GetHttpClient(), GetLogger(), loginClient, clientSecret, port), cfg, log)
+			return
+		}
+		NotifyErrorsWithRetry(LoginCmd(cfg, GetHttpClient(), log, loginClient, clientSecret, username, password, loginSsoPasscode, port), cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(loginCmd)
+	loginCmd.Annotations = make(map[string]string)
+	loginCmd.Annotations[INTRO_CATEGORY] = "true"
+	loginCmd.Flags().StringVarP(&loginClient, "client", "", "cf", "client to log in with")
+	loginCmd.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "client secret, if the client has one")
+	loginCmd.Flags().StringVarP(&username, "username", "u", "", "username (prompted for when omitted)")
+	loginCmd.Flags().StringVarP(&password, "password", "p", "", "user password (prompted for when omitted)")
+	loginCmd.Flags().StringVarP(&mfaCode, "mfa-code", "", "", "multi-factor authentication code (prompted for when the UAA requires one)")
+	loginCmd.Flags().StringVarP(&origin, "origin", "o", "", "origin of the identity provider which should authenticate the user, sent as login_hint")
+	addScopeFlag(loginCmd)
+	loginCmd.Flags().BoolVarP(&loginSso, "sso", "", false, "log in with a browser, e.g. through an external identity provider")
+	loginCmd.Flags().BoolVarP(&loginSsoPasscode, "sso-passcode", "", false, "log in with a one-time passcode obtained from the login server in a browser")
+	loginCmd.Flags().IntVarP(&port, "port", "", 0, "port on which to run the local callback server for --sso")
+	loginCmd.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
+}
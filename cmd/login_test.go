@@ -0,0 +1,228 @@
+package cmd_test
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"net/http"
+	"strings"
+)
+
+var _ = Describe("Login", func() {
+	const tokenResponseJson = `{
+	  "access_token" : "bc4885d950854fed9a938e96b13ca519",
+	  "token_type" : "bearer",
+	  "expires_in" : 43199,
+	  "scope" : "openid",
+	  "jti" : "bc4885d950854fed9a938e96b13ca519"
+	}`
+
+	BeforeEach(func() {
+		config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+		server.RouteToHandler("GET", "/info", RespondWith(http.StatusOK, InfoResponseJson))
+	})
+
+	It("logs in with the password grant and the cf client", func() {
+		server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+			VerifyFormKV("client_id", "cf"),
+			VerifyFormKV("client_secret", ""),
+			VerifyFormKV("grant_type", "password"),
+			VerifyFormKV("username", "woodstock"),
+			VerifyFormKV("password", "secret"),
+			RespondWith(http.StatusOK, tokenResponseJson),
+		))
+
+		session := runCommand("login", "-u", "woodstock", "-p", "secret")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("Access token successfully fetched and added to context."))
+		ctx := config.ReadConfig().GetActiveContext()
+		Expect(ctx.ClientId).To(Equal("cf"))
+		Expect(ctx.Username).To(Equal("woodstock"))
+		Expect(ctx.GrantType).To(Equal(uaa.PASSWORD))
+		Expect(ctx.AccessToken).To(Equal("bc4885d950854fed9a938e96b13ca519"))
+	})
+
+	It("prompts for credentials using the labels declared by the UAA", func() {
+		server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+			VerifyFormKV("username", "woodstock@example.com"),
+			VerifyFormKV("password", "secret"),
+			RespondWith(http.StatusOK, tokenResponseJson),
+		))
+
+		session := runCommandWithStdin(strings.NewReader("woodstock@example.com\n"), "login", "-p", "secret")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("Email: "))
+		Expect(config.ReadConfig().GetActiveContext().Username).To(Equal("woodstock@example.com"))
+	})
+
+	It("can log in with another client", func() {
+		server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+			VerifyFormKV("client_id", "shinyclient"),
+			VerifyFormKV("client_secret", "shinysecret"),
+			RespondWith(http.StatusOK, tokenResponseJson),
+		))
+
+		session := runCommand("login", "-u", "woodstock", "-p", "secret", "--client", "shinyclient", "-s", "shinysecret")
+
+		Eventually(session).Should(Exit(0))
+		Expect(config.ReadConfig().GetActiveContext().ClientId).To(Equal("shinyclient"))
+	})
+
+	It("prompts for the other prompts declared by the UAA and sends the answers", func() {
+		server.RouteToHandler("GET", "/info", RespondWith(http.StatusOK, `{
+		  "app": {"version": "4.5.0"},
+		  "prompts": {
+		    "username": ["text", "Email"],
+		    "password": ["password", "Password"],
+		    "passcode": ["password", "One Time Code"],
+		    "tenant": ["text", "Tenant"]
+		  }
+		}`))
+		server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+			VerifyFormKV("username", "woodstock"),
+			VerifyFormKV("password", "secret"),
+			VerifyFormKV("tenant", "peanuts"),
+			RespondWith(http.StatusOK, tokenResponseJson),
+		))
+
+		session := runCommandWithStdin(strings.NewReader("peanuts\n"), "login", "-u", "woodstock", "-p", "secret")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("Tenant: "))
+		Expect(session.Out).NotTo(Say("One Time Code"))
+		Expect(server.ReceivedRequests()[1].Form).NotTo(HaveKey("passcode"))
+	})
+
+	Describe("when external identity providers are configured", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/info", RespondWith(http.StatusOK, `{
+			  "app": {"version": "4.5.0"},
+			  "links": {"login": "https://login.run.pivotal.io"},
+			  "idpDefinitions": {"okta": "http://localhost:8080/uaa/saml/discovery?idp=okta"},
+			  "prompts": {"username": ["text", "Email"], "password": ["password", "Password"]}
+			}`))
+		})
+
+		It("logs in with a password by default", func() {
+			server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+				VerifyFormKV("username", "woodstock"),
+				VerifyFormKV("password", "secret"),
+				RespondWith(http.StatusOK, tokenResponseJson),
+			))
+
+			session := runCommandWithStdin(strings.NewReader("\nwoodstock\n"), "login", "-p", "secret")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("This UAA also authenticates users with okta. How do you want to log in?"))
+			Expect(session.Out).To(Say("Login method \\[1\\]: "))
+			Expect(session.Out).To(Say("Email: "))
+			Expect(config.ReadConfig().GetActiveContext().Username).To(Equal("woodstock"))
+		})
+
+		It("logs in with a one-time passcode when chosen", func() {
+			server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+				VerifyFormKV("grant_type", "password"),
+				VerifyFormKV("passcode", "Wj5DXN"),
+				RespondWith(http.StatusOK, tokenResponseJson),
+			))
+			server.RouteToHandler("GET", "/userinfo", RespondWith(http.StatusOK, `{"user_name": "woodstock"}`))
+
+			session := runCommandWithStdin(strings.NewReader("3\nWj5DXN\n"), "login")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Get a one-time passcode at https://login.run.pivotal.io/passcode"))
+			Expect(config.ReadConfig().GetActiveContext().AccessToken).To(Equal("bc4885d950854fed9a938e96b13ca519"))
+		})
+
+		It("requires a port to log in with a browser", func() {
+			session := runCommandWithStdin(strings.NewReader("2\n"), "login")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `port` must be specified."))
+		})
+
+		It("rejects unknown login methods", func() {
+			session := runCommandWithStdin(strings.NewReader("4\n"), "login")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("4 is not a login method. Enter 1, 2 or 3."))
+		})
+
+		It("does not ask when a username is given", func() {
+			server.RouteToHandler("POST", "/oauth/token", RespondWith(http.StatusOK, tokenResponseJson))
+
+			session := runCommand("login", "-u", "woodstock", "-p", "secret")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).NotTo(Say("How do you want to log in?"))
+		})
+	})
+
+	It("displays an error when the login fails", func() {
+		server.RouteToHandler("POST", "/oauth/token", RespondWith(http.StatusUnauthorized, `{"error": "unauthorized"}`))
+
+		session := runCommand("login", "-u", "woodstock", "-p", "wrong")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("An error occurred while fetching token."))
+	})
+
+	It("displays an error when the prompts cannot be fetched", func() {
+		server.RouteToHandler("GET", "/info", RespondWith(http.StatusInternalServerError, ""))
+
+		session := runCommand("login", "-u", "woodstock", "-p", "secret")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("An error occurred while fetching the login prompts of the target."))
+	})
+
+	Describe("with a one-time passcode", func() {
+		It("prints the passcode page and logs in with the entered passcode", func() {
+			server.RouteToHandler("POST", "/oauth/token", CombineHandlers(
+				VerifyFormKV("client_id", "cf"),
+				VerifyFormKV("grant_type", "password"),
+				VerifyFormKV("passcode", "Wj5DXN"),
+				RespondWith(http.StatusOK, tokenResponseJson),
+			))
+			server.RouteToHandler("GET", "/userinfo", RespondWith(http.StatusOK, `{"user_name": "woodstock"}`))
+
+			session := runCommandWithStdin(strings.NewReader("Wj5DXN\n"), "login", "--sso-passcode")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Get a one-time passcode at https://login.run.pivotal.io/passcode"))
+			Expect(session.Out).To(Say("Passcode: "))
+			Expect(config.ReadConfig().GetActiveContext().AccessToken).To(Equal("bc4885d950854fed9a938e96b13ca519"))
+		})
+
+		It("requires a passcode", func() {
+			session := runCommandWithStdin(strings.NewReader("\n"), "login", "--sso-passcode")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("A passcode is required to log in with a one-time passcode."))
+		})
+	})
+
+	Describe("Validations", func() {
+		It("requires a port for --sso", func() {
+			session := runCommand("login", "--sso")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `port` must be specified."))
+		})
+
+		It("requires a target", func() {
+			config.WriteConfig(uaa.NewConfig())
+
+			session := runCommand("login")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("You must set a target in order to use this command."))
+		})
+	})
+})
@@ -11,11 +11,18 @@ import (
 	"net/http"
 )
 
-func RefreshTokenCmd(cfg uaa.Config, httpClient *http.Client, log cli.Logger, tokenFormat string) error {
+func RefreshTokenCmd(cfg uaa.Config, httpClient *http.Client, log cli.Logger, tokenFormat, scope string) error {
 	ctx := cfg.GetActiveContext()
+	clientAuth, err := buildClientAuthentication(ctx.ClientId, clientSecret)
+	if err != nil {
+		return err
+	}
+
 	refreshClient := uaa.RefreshTokenClient{
 		ClientId:     ctx.ClientId,
 		ClientSecret: clientSecret,
+		ClientAuth:   clientAuth,
+		Scope:        requestedScope(scope),
 	}
 	log.Infof("Using the refresh_token from the active context to request a new access token for client %v.", utils.Emphasize(ctx.ClientId))
 	tokenResponse, err := refreshClient.RequestToken(httpClient, cfg, uaa.TokenFormat(tokenFormat), ctx.RefreshToken)
@@ -27,6 +34,7 @@ func RefreshTokenCmd(cfg uaa.Config, httpClient *http.Client, log cli.Logger, to
 	cfg.AddContext(ctx)
 	config.WriteConfig(cfg)
 	log.Info("Access token successfully fetched and added to active context.")
+	warnOnScopeMismatch(log, requestedScope(scope), tokenResponse.Scope)
 	return nil
 }
 
@@ -34,8 +42,8 @@ func RefreshTokenValidations(cfg uaa.Config, clientSecret string) error {
 	if err := EnsureContextInConfig(cfg); err != nil {
 		return err
 	}
-	if clientSecret == "" {
-		return MissingArgumentError("client_secret")
+	if err := validateClientAuthentication(clientSecret); err != nil {
+		return err
 	}
 	if cfg.GetActiveContext().ClientId == "" {
 		return errors.New("A client_id was not found in the active context.")
@@ -57,7 +65,7 @@ var refreshTokenCmd = &cobra.Command{
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := GetSavedConfig()
-		NotifyErrorsWithRetry(RefreshTokenCmd(cfg, GetHttpClient(), log, tokenFormat), cfg, log)
+		NotifyErrorsWithRetry(RefreshTokenCmd(cfg, GetHttpClient(), log, tokenFormat, tokenScope), cfg, log)
 	},
 }
 
@@ -66,5 +74,7 @@ func init() {
 	refreshTokenCmd.Annotations = make(map[string]string)
 	refreshTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
 	refreshTokenCmd.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "client secret")
+	addClientAuthenticationFlags(refreshTokenCmd, uaa.CLIENT_SECRET_POST)
+	addScopeFlag(refreshTokenCmd)
 	refreshTokenCmd.Flags().StringVarP(&tokenFormat, "format", "", "jwt", "available formats include "+availableFormatsStr())
 }
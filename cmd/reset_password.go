@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+func ResetPasswordCmd(httpClient *http.Client, config uaa.Config, log cli.Logger, code, password string, generate bool) error {
+	password, err := newPassword(httpClient, config, log, password, generate)
+	if err != nil {
+		return err
+	}
+
+	um := uaa.UserManager{httpClient, config}
+	result, err := um.ResetPassword(code, password)
+	if err != nil {
+		return errors.New(describeError(err))
+	}
+
+	log.Infof("The password of user %v was reset.", utils.Emphasize(result.Username))
+	if generate {
+		printGeneratedPassword(log, password)
+	}
+	return nil
+}
+
+func ResetPasswordValidations(cfg uaa.Config, args []string, password string, generate bool) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("The positional argument CODE must be specified.")
+	}
+	return validatePasswordSource(password, generate)
+}
+
+var resetPasswordCmd = &cobra.Command{
+	Use:   "reset-password CODE",
+	Short: "Set a new password with a password reset code",
+	Long:  help.ResetPassword(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(ResetPasswordValidations(GetSavedConfig(), args, userPassword, generatePassword), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		err := ResetPasswordCmd(GetHttpClient(), cfg, log, args[0], userPassword, generatePassword)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(resetPasswordCmd)
+	resetPasswordCmd.Annotations = make(map[string]string)
+	resetPasswordCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	resetPasswordCmd.Flags().StringVarP(&userPassword, "password", "p", "", "the new password. You are prompted for it when it is not given")
+	resetPasswordCmd.Flags().BoolVarP(&generatePassword, "generate", "", false, "generate a random password satisfying the zone's password policy and print it")
+	resetPasswordCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain in which the code was created")
+}
@@ -0,0 +1,81 @@
+package cmd_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ResetPassword", func() {
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("reset-password", "yCQL6a0dJr", "--password", "n3w")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a code", func() {
+			session := runCommand("reset-password", "--password", "n3w")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument CODE must be specified."))
+		})
+
+		It("does not take both --password and --generate", func() {
+			session := runCommand("reset-password", "yCQL6a0dJr", "--password", "n3w", "--generate")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Use either --password or --generate."))
+		})
+	})
+
+	It("sets the new password with the code", func() {
+		server.RouteToHandler("POST", "/password_change", CombineHandlers(
+			VerifyHeaderKV("Authorization", "bearer access_token"),
+			VerifyJSON(`{"code": "yCQL6a0dJr", "new_password": "n3w"}`),
+			RespondWith(http.StatusOK, `{"user_id": "abcdef", "username": "woodstock", "email": "woodstock@peanuts.com"}`),
+		))
+
+		session := runCommand("reset-password", "yCQL6a0dJr", "--password", "n3w")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("The password of user woodstock was reset."))
+	})
+
+	It("prints generated passwords", func() {
+		server.RouteToHandler("GET", "/identity-providers", RespondWith(http.StatusOK, `[]`))
+		server.RouteToHandler("POST", "/password_change", RespondWith(http.StatusOK, `{"user_id": "abcdef", "username": "woodstock"}`))
+
+		session := runCommand("reset-password", "yCQL6a0dJr", "--generate")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("The password of user woodstock was reset."))
+		Expect(session.Out).To(Say("The new password is"))
+	})
+
+	It("shows why the code was rejected", func() {
+		server.RouteToHandler("POST", "/password_change", RespondWith(http.StatusUnprocessableEntity,
+			`{"error": "invalid_code", "error_description": "Sorry, your reset password link is no longer valid."}`))
+
+		session := runCommand("reset-password", "yCQL6a0dJr", "--password", "n3w")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("Sorry, your reset password link is no longer valid."))
+	})
+})
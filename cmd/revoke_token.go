@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"net/http"
+)
+
+// Token revocation flags
+var (
+	revokeUser    string
+	revokeClient  string
+	tokenTypeHint string
+	rfc7009       bool
+)
+
+func availableTokenTypeHints() []string {
+	return []string{"access_token", "refresh_token"}
+}
+
+func markActiveContextRevoked(cfg uaa.Config, log cli.Logger) {
+	ctx := cfg.GetActiveContext()
+	ctx.Revoked = true
+	cfg.AddContext(ctx)
+	config.WriteConfig(cfg)
+	log.Info("The token in the active context has been revoked and will no longer be used. Obtain a new token to continue.")
+}
+
+func RevokeTokenCmd(cfg uaa.Config, httpClient *http.Client, log cli.Logger, tokenId, username, clientId string) error {
+	ctx := cfg.GetActiveContext()
+	tr := uaa.TokenRevoker{HttpClient: httpClient, Config: cfg}
+
+	var revokedActiveContext bool
+	switch {
+	case username != "":
+		um := uaa.UserManager{HttpClient: httpClient, Config: cfg}
+		user, err := um.GetByUsername(username, origin, "")
+		if err != nil {
+			return err
+		}
+		if clientId != "" {
+			if err := tr.RevokeUserClientTokens(user.ID, clientId); err != nil {
+				return err
+			}
+			log.Infof("All tokens for user %v and client %v successfully revoked.", utils.Emphasize(user.Username), utils.Emphasize(clientId))
+			revokedActiveContext = ctx.Username == user.Username && ctx.ClientId == clientId
+		} else {
+			if err := tr.RevokeUserTokens(user.ID); err != nil {
+				return err
+			}
+			log.Infof("All tokens for user %v successfully revoked.", utils.Emphasize(user.Username))
+			revokedActiveContext = ctx.Username == user.Username
+		}
+	case clientId != "":
+		if err := tr.RevokeClientTokens(clientId); err != nil {
+			return err
+		}
+		log.Infof("All tokens for client %v successfully revoked.", utils.Emphasize(clientId))
+		revokedActiveContext = ctx.ClientId == clientId
+	default:
+		if tokenId == "" {
+			tokenId = ctx.JTI
+		}
+		if err := tr.RevokeToken(tokenId); err != nil {
+			return err
+		}
+		log.Infof("Token %v successfully revoked.", utils.Emphasize(tokenId))
+		revokedActiveContext = ctx.JTI == tokenId || ctx.AccessToken == tokenId
+	}
+
+	if revokedActiveContext {
+		markActiveContextRevoked(cfg, log)
+	}
+	return nil
+}
+
+func RevokeTokenRfc7009Cmd(cfg uaa.Config, httpClient *http.Client, log cli.Logger, clientId, clientSecret, token, tokenTypeHint string) error {
+	ctx := cfg.GetActiveContext()
+	if clientId == "" {
+		clientId = ctx.ClientId
+	}
+	if token == "" {
+		token = ctx.AccessToken
+	}
+
+	clientAuth, err := buildClientAuthentication(clientId, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	rc := uaa.RevocationClient{ClientId: clientId, ClientSecret: clientSecret, ClientAuth: clientAuth}
+	if err := rc.Revoke(httpClient, cfg, token, tokenTypeHint); err != nil {
+		return errors.New("An error occurred while revoking token.")
+	}
+	log.Info("Token successfully revoked.")
+
+	if token == ctx.AccessToken || token == ctx.RefreshToken {
+		markActiveContextRevoked(cfg, log)
+	}
+	return nil
+}
+
+func RevokeTokenValidations(cfg uaa.Config, args []string) error {
+	if rfc7009 {
+		return revokeTokenRfc7009Validations(cfg, args)
+	}
+
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) > 0 && (revokeUser != "" || revokeClient != "") {
+		return errors.New("A token ID cannot be combined with the --user or --client flags.")
+	}
+	if tokenTypeHint != "" {
+		return errors.New("The --token-type-hint flag may only be used with --rfc7009.")
+	}
+	if len(args) == 0 && revokeUser == "" && revokeClient == "" && cfg.GetActiveContext().JTI == "" {
+		return MissingArgumentWithExplanationError("token_id", "There is no jti in the active context to revoke.")
+	}
+	return nil
+}
+
+func revokeTokenRfc7009Validations(cfg uaa.Config, args []string) error {
+	if err := EnsureTargetInConfig(cfg); err != nil {
+		return err
+	}
+	if revokeUser != "" {
+		return errors.New("The --user flag cannot be used with --rfc7009.")
+	}
+	if revokeClient == "" && cfg.GetActiveContext().ClientId == "" {
+		return MissingArgumentWithExplanationError("client", "There is no client_id in the active context.")
+	}
+	if len(args) == 0 && cfg.GetActiveContext().AccessToken == "" {
+		return MissingArgumentWithExplanationError("token", "There is no access_token in the active context to revoke.")
+	}
+	if tokenTypeHint != "" && !utils.Contains(availableTokenTypeHints(), tokenTypeHint) {
+		return fmt.Errorf(`The token type hint "%v" is unknown. Available hints: %v`, tokenTypeHint, utils.StringSliceStringifier(availableTokenTypeHints()))
+	}
+	return validateClientAuthentication(clientSecret)
+}
+
+var revokeTokenCmd = &cobra.Command{
+	Use:   "revoke-token [TOKEN_ID | --rfc7009 TOKEN]",
+	Short: "Revoke a token, or all tokens for a user or client",
+	Long:  help.RevokeToken(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(RevokeTokenValidations(cfg, args), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		var arg string
+		if len(args) > 0 {
+			arg = args[0]
+		}
+
+		var err error
+		if rfc7009 {
+			err = RevokeTokenRfc7009Cmd(cfg, GetHttpClient(), log, revokeClient, clientSecret, arg, tokenTypeHint)
+		} else {
+			err = RevokeTokenCmd(cfg, GetHttpClient(), log, arg, revokeUser, revokeClient)
+		}
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(revokeTokenCmd)
+	revokeTokenCmd.Annotations = make(map[string]string)
+	revokeTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
+
+	revokeTokenCmd.Flags().StringVarP(&revokeUser, "user", "", "", "revoke all tokens issued to the user with this username")
+	revokeTokenCmd.Flags().StringVarP(&origin, "origin", "o", "", "the identity provider in which to search for the user")
+	revokeTokenCmd.Flags().StringVarP(&revokeClient, "client", "", "", "revoke all tokens issued to this client, or to the user for this client when combined with --user; with --rfc7009, the client to authenticate as (defaults to the client of the active context)")
+	revokeTokenCmd.Flags().BoolVarP(&rfc7009, "rfc7009", "", false, "revoke TOKEN (defaults to the access_token of the active context) using the /oauth/revoke endpoint")
+	revokeTokenCmd.Flags().StringVarP(&tokenTypeHint, "token-type-hint", "", "", "with --rfc7009, the type of the token, one of "+utils.StringSliceStringifier(availableTokenTypeHints()))
+	revokeTokenCmd.Flags().StringVarP(&clientSecret, "client_secret", "s", "", "with --rfc7009, client secret")
+	addClientAuthenticationFlags(revokeTokenCmd, uaa.CLIENT_SECRET_POST)
+}
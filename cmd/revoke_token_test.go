@@ -0,0 +1,217 @@
+package cmd_test
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"net/http"
+)
+
+var _ = Describe("RevokeToken", func() {
+	BeforeEach(func() {
+		c := uaa.NewConfigWithServerURL(server.URL())
+		ctx := uaa.NewContextWithToken("access_token")
+		ctx.ClientId = "cf"
+		ctx.Username = "woodstock@peanuts.com"
+		ctx.GrantType = uaa.PASSWORD
+		ctx.RefreshToken = "refresh_token"
+		ctx.JTI = "active-jti"
+		c.AddContext(ctx)
+		config.WriteConfig(c)
+	})
+
+	Describe("by token id", func() {
+		It("revokes the token of the active context by default", func() {
+			server.RouteToHandler("DELETE", "/oauth/token/revoke/active-jti", CombineHandlers(
+				VerifyHeaderKV("Authorization", "bearer access_token"),
+				RespondWith(http.StatusOK, ""),
+			))
+
+			session := runCommand("revoke-token")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Token active-jti successfully revoked."))
+			Expect(session.Out).To(Say("The token in the active context has been revoked"))
+			Expect(config.ReadConfig().GetActiveContext().Revoked).To(BeTrue())
+		})
+
+		It("revokes another token without marking the active context", func() {
+			server.RouteToHandler("DELETE", "/oauth/token/revoke/other-jti", CombineHandlers(
+				RespondWith(http.StatusOK, ""),
+			))
+
+			session := runCommand("revoke-token", "other-jti")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Token other-jti successfully revoked."))
+			Expect(config.ReadConfig().GetActiveContext().Revoked).To(BeFalse())
+		})
+
+		It("no longer sends a revoked token", func() {
+			server.RouteToHandler("DELETE", "/oauth/token/revoke/active-jti", RespondWith(http.StatusOK, ""))
+			Eventually(runCommand("revoke-token")).Should(Exit(0))
+
+			session := runCommand("revoke-token", "other-jti")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The access token in the active context has been revoked."))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("displays an error when revocation fails", func() {
+			server.RouteToHandler("DELETE", "/oauth/token/revoke/other-jti", RespondWith(http.StatusForbidden, ""))
+
+			session := runCommand("revoke-token", "other-jti")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("An unknown error occurred while calling"))
+		})
+	})
+
+	Describe("by user and client", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/Users", CombineHandlers(
+				VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock@peanuts.com%22"),
+				RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock@peanuts.com", ID: "abcdef"})),
+			))
+		})
+
+		It("revokes all tokens for a user", func() {
+			server.RouteToHandler("GET", "/oauth/token/revoke/user/abcdef", RespondWith(http.StatusOK, ""))
+
+			session := runCommand("revoke-token", "--user", "woodstock@peanuts.com")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("All tokens for user woodstock@peanuts.com successfully revoked."))
+			Expect(config.ReadConfig().GetActiveContext().Revoked).To(BeTrue())
+		})
+
+		It("revokes all tokens for a user and client", func() {
+			server.RouteToHandler("GET", "/oauth/token/revoke/user/abcdef/client/notifier", RespondWith(http.StatusOK, ""))
+
+			session := runCommand("revoke-token", "--user", "woodstock@peanuts.com", "--client", "notifier")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("All tokens for user woodstock@peanuts.com and client notifier successfully revoked."))
+			Expect(config.ReadConfig().GetActiveContext().Revoked).To(BeFalse())
+		})
+
+		It("revokes all tokens for a client", func() {
+			server.RouteToHandler("GET", "/oauth/token/revoke/client/cf", RespondWith(http.StatusOK, ""))
+
+			session := runCommand("revoke-token", "--client", "cf")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("All tokens for client cf successfully revoked."))
+			Expect(config.ReadConfig().GetActiveContext().Revoked).To(BeTrue())
+		})
+	})
+
+	Describe("with --rfc7009", func() {
+		It("posts the active access token to /oauth/revoke", func() {
+			server.RouteToHandler("POST", "/oauth/revoke", CombineHandlers(
+				VerifyFormKV("token", "access_token"),
+				VerifyFormKV("client_id", "cf"),
+				VerifyFormKV("client_secret", "cfsecret"),
+				RespondWith(http.StatusOK, ""),
+			))
+
+			session := runCommand("revoke-token", "--rfc7009", "-s", "cfsecret")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Token successfully revoked."))
+			Expect(config.ReadConfig().GetActiveContext().Revoked).To(BeTrue())
+		})
+
+		It("takes the token to revoke as its argument and keeps the active client", func() {
+			server.RouteToHandler("POST", "/oauth/revoke", CombineHandlers(
+				VerifyFormKV("token", "leaked-token"),
+				VerifyFormKV("client_id", "cf"),
+				VerifyFormKV("client_secret", "cfsecret"),
+				RespondWith(http.StatusOK, ""),
+			))
+
+			session := runCommand("revoke-token", "leaked-token", "--rfc7009", "-s", "cfsecret")
+
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Token successfully revoked."))
+			Expect(config.ReadConfig().GetActiveContext().Revoked).To(BeFalse())
+		})
+
+		It("revokes a given token as the given client", func() {
+			server.RouteToHandler("POST", "/oauth/revoke", CombineHandlers(
+				VerifyBasicAuth("notifier", "secret"),
+				VerifyFormKV("token", "some-other-token"),
+				VerifyFormKV("token_type_hint", "refresh_token"),
+				RespondWith(http.StatusOK, ""),
+			))
+
+			session := runCommand("revoke-token", "some-other-token", "--rfc7009", "--client", "notifier", "-s", "secret", "--auth-method", "client_secret_basic",
+				"--token-type-hint", "refresh_token")
+
+			Eventually(session).Should(Exit(0))
+			Expect(config.ReadConfig().GetActiveContext().Revoked).To(BeFalse())
+		})
+
+		It("displays an error when revocation fails", func() {
+			server.RouteToHandler("POST", "/oauth/revoke", RespondWith(http.StatusUnauthorized, `{"error":"invalid_client"}`))
+
+			session := runCommand("revoke-token", "--rfc7009", "-s", "wrong")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("An error occurred while revoking token."))
+			Expect(config.ReadConfig().GetActiveContext().Revoked).To(BeFalse())
+		})
+
+		It("requires a client secret", func() {
+			session := runCommand("revoke-token", "--rfc7009")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Missing argument `client_secret` must be specified."))
+		})
+
+		It("rejects unknown token type hints", func() {
+			session := runCommand("revoke-token", "--rfc7009", "-s", "cfsecret", "--token-type-hint", "id_token")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(`The token type hint "id_token" is unknown.`))
+		})
+	})
+
+	Describe("validations", func() {
+		It("requires a context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("revoke-token", "some-jti")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("You must have a token in your context to perform this command."))
+		})
+
+		It("does not combine a token id with --user", func() {
+			session := runCommand("revoke-token", "some-jti", "--user", "woodstock@peanuts.com")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("A token ID cannot be combined with the --user or --client flags."))
+		})
+
+		It("only accepts --token-type-hint with --rfc7009", func() {
+			session := runCommand("revoke-token", "--token-type-hint", "access_token")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The --token-type-hint flag may only be used with --rfc7009."))
+		})
+
+		It("does not combine --user with --rfc7009", func() {
+			session := runCommand("revoke-token", "--rfc7009", "--user", "woodstock", "-s", "cfsecret")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The --user flag cannot be used with --rfc7009."))
+		})
+	})
+})
@@ -38,6 +38,7 @@ var (
 	displayName          string
 	scope                string
 	redirectUri          string
+	redirectClientId     string
 	clone                string
 	zoneSubdomain        string
 	port                 int
@@ -66,6 +67,14 @@ var (
 	attributes string
 	count      int
 	startIndex int
+	listAll    bool
+)
+
+// Bulk flags
+var (
+	concurrency int
+	dryRun      bool
+	force       bool
 )
 
 // Curl flags
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+// Password flags
+var (
+	oldPassword      string
+	generatePassword bool
+)
+
+func promptForSecret(label string) (string, error) {
+	secret := cli.InteractiveSecret{Prompt: label}
+	value, err := secret.Get()
+	cli.InteractiveOutput.Write([]byte("\n"))
+	return value, err
+}
+
+// newPassword returns the password given with --password, a password generated
+// according to the zone's password policy, or one entered twice at a prompt.
+func newPassword(httpClient *http.Client, config uaa.Config, log cli.Logger, password string, generate bool) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+
+	if generate {
+		policy, err := uaa.GetPasswordPolicy(httpClient, config)
+		if err != nil {
+			log.Warn("The password policy of the zone could not be read. The generated password may not satisfy it.")
+		}
+		return policy.Generate()
+	}
+
+	password, err := promptForSecret("New password")
+	if err != nil {
+		return "", err
+	}
+	confirmation, err := promptForSecret("Confirm new password")
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", errors.New("The new password may not be blank.")
+	}
+	if password != confirmation {
+		return "", errors.New("The passwords do not match.")
+	}
+	return password, nil
+}
+
+func printGeneratedPassword(log cli.Logger, password string) {
+	log.Infof("The new password is %v", utils.Emphasize(password))
+	log.Info("It will not be shown again.")
+}
+
+func SetUserPasswordCmd(httpClient *http.Client, config uaa.Config, log cli.Logger, username, origin, password string, generate bool) error {
+	um := uaa.UserManager{httpClient, config}
+	user, err := um.GetByUsername(username, origin, "id,userName")
+	if err != nil {
+		return err
+	}
+
+	password, err = newPassword(httpClient, config, log, password, generate)
+	if err != nil {
+		return err
+	}
+
+	if err := um.SetPassword(user.ID, password, ""); err != nil {
+		return errors.New(describeError(err))
+	}
+
+	log.Infof("The password of user %v was changed.", utils.Emphasize(user.Username))
+	if generate {
+		printGeneratedPassword(log, password)
+	}
+	return nil
+}
+
+func validatePasswordSource(password string, generate bool) error {
+	if password != "" && generate {
+		return errors.New("Use either --password or --generate.")
+	}
+	return nil
+}
+
+func SetUserPasswordValidations(cfg uaa.Config, args []string, password string, generate bool) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("The positional argument USERNAME must be specified.")
+	}
+	return validatePasswordSource(password, generate)
+}
+
+var setUserPasswordCmd = &cobra.Command{
+	Use:   "set-user-password USERNAME",
+	Short: "Set the password of a user as an administrator",
+	Long:  help.SetUserPassword(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(SetUserPasswordValidations(GetSavedConfig(), args, userPassword, generatePassword), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		err := SetUserPasswordCmd(GetHttpClient(), cfg, log, args[0], origin, userPassword, generatePassword)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(setUserPasswordCmd)
+	setUserPasswordCmd.Annotations = make(map[string]string)
+	setUserPasswordCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	setUserPasswordCmd.Flags().StringVarP(&origin, "origin", "o", "", `The identity provider in which to search. Examples: uaa, ldap, etc. `)
+	setUserPasswordCmd.Flags().StringVarP(&userPassword, "password", "p", "", "the new password. You are prompted for it when it is not given")
+	setUserPasswordCmd.Flags().BoolVarP(&generatePassword, "generate", "", false, "generate a random password satisfying the zone's password policy and print it")
+	setUserPasswordCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain in which to set the password")
+}
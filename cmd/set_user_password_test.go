@@ -0,0 +1,112 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("SetUserPassword", func() {
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock%22&attributes=id%2CuserName"),
+			RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock", ID: "abcdef"})),
+		))
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("set-user-password", "woodstock")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a username", func() {
+			session := runCommand("set-user-password")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument USERNAME must be specified."))
+		})
+
+		It("does not take both --password and --generate", func() {
+			session := runCommand("set-user-password", "woodstock", "--password", "secret", "--generate")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Use either --password or --generate."))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	It("sets the password given with --password", func() {
+		server.RouteToHandler("PUT", "/Users/abcdef/password", CombineHandlers(
+			VerifyHeaderKV("Authorization", "bearer access_token"),
+			VerifyJSON(`{"password": "s3cret"}`),
+			RespondWith(http.StatusOK, `{"status": "ok"}`),
+		))
+
+		session := runCommand("set-user-password", "woodstock", "--password", "s3cret")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("The password of user woodstock was changed."))
+		Expect(session.Out).NotTo(Say("s3cret"))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("generates a password satisfying the policy and prints it once", func() {
+		var sent struct{ Password string }
+		server.RouteToHandler("GET", "/identity-providers", CombineHandlers(
+			VerifyRequest("GET", "/identity-providers", "rawConfig=true"),
+			RespondWith(http.StatusOK, `[{"originKey": "uaa", "config": {"passwordPolicy": {"minLength": 30}}}]`),
+		))
+		server.RouteToHandler("PUT", "/Users/abcdef/password", func(w http.ResponseWriter, req *http.Request) {
+			json.NewDecoder(req.Body).Decode(&sent)
+			w.Write([]byte(`{"status": "ok"}`))
+		})
+
+		session := runCommand("set-user-password", "woodstock", "--generate")
+
+		Eventually(session).Should(Exit(0))
+		Expect(sent.Password).To(HaveLen(30))
+		Expect(session.Out).To(Say("The password of user woodstock was changed."))
+		Expect(session.Out).To(Say("The new password is %s", regexp.QuoteMeta(sent.Password)))
+		Expect(session.Out).To(Say("It will not be shown again."))
+	})
+
+	It("warns when the password policy cannot be read", func() {
+		server.RouteToHandler("GET", "/identity-providers", RespondWith(http.StatusForbidden, `{"error": "insufficient_scope"}`))
+		server.RouteToHandler("PUT", "/Users/abcdef/password", RespondWith(http.StatusOK, `{"status": "ok"}`))
+
+		session := runCommand("set-user-password", "woodstock", "--generate")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("The password policy of the zone could not be read."))
+		Expect(session.Out).To(Say("The new password is"))
+	})
+
+	It("shows why the UAA rejected the password", func() {
+		server.RouteToHandler("PUT", "/Users/abcdef/password", RespondWith(http.StatusUnprocessableEntity,
+			`{"error": "invalid_password", "error_description": "Password must be at least 8 characters in length."}`))
+
+		session := runCommand("set-user-password", "woodstock", "--password", "short")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("Password must be at least 8 characters in length."))
+	})
+})
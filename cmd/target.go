@@ -45,11 +45,20 @@ func UpdateTargetCmd(cfg uaa.Config, newTarget string, log cli.Logger) error {
 	}
 
 	cfg.AddTarget(target)
-	_, err := uaa.Info(GetHttpClientWithConfig(cfg), cfg)
+	httpClient := GetHttpClientWithConfig(cfg)
+	_, err := uaa.Info(httpClient, cfg)
 	if err != nil {
 		return errors.New(fmt.Sprintf("The target %s could not be set.", newTarget))
 	}
 
+	// Older UAAs do not publish a discovery document; their endpoints are
+	// then assumed to be on the target itself.
+	discovery, err := uaa.Discover(httpClient, cfg)
+	if err == nil {
+		target.Discovery = &discovery
+		cfg.AddTarget(target)
+	}
+
 	config.WriteConfig(cfg)
 	log.Info("Target set to " + utils.Emphasize(newTarget))
 	return nil
@@ -90,6 +90,9 @@ var _ = Describe("Target", func() {
 				server.RouteToHandler("GET", "/info",
 					RespondWith(http.StatusOK, InfoResponseJson),
 				)
+				server.RouteToHandler("GET", "/.well-known/openid-configuration",
+					RespondWith(http.StatusOK, DiscoveryResponseJson),
+				)
 
 				c := uaa.NewConfig()
 				config.WriteConfig(c)
@@ -118,6 +121,29 @@ var _ = Describe("Target", func() {
 				runCommand("target", server.URL(), "--skip-ssl-validation")
 				Expect(config.ReadConfig().GetActiveTarget().SkipSSLValidation).To(BeTrue())
 			})
+
+			It("saves the OpenID Connect discovery document with the target", func() {
+				session := runCommand("target", server.URL())
+
+				Eventually(session).Should(Exit(0))
+				target := config.ReadConfig().GetActiveTarget()
+				Expect(target.Discovery).NotTo(BeNil())
+				Expect(target.TokenEndpoint()).To(Equal("https://login.example.com/oauth/token"))
+				Expect(target.JwksUri()).To(Equal("https://login.example.com/token_keys"))
+			})
+
+			It("uses the UAA's own endpoints when there is no discovery document", func() {
+				server.RouteToHandler("GET", "/.well-known/openid-configuration",
+					RespondWith(http.StatusNotFound, ""),
+				)
+
+				session := runCommand("target", server.URL())
+
+				Eventually(session).Should(Exit(0))
+				target := config.ReadConfig().GetActiveTarget()
+				Expect(target.Discovery).To(BeNil())
+				Expect(target.TokenEndpoint()).To(Equal("/oauth/token"))
+			})
 		})
 
 		Describe("when the UAA cannot be reached", func() {
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Token key output flags
+var (
+	tokenKeyOutput string
+	tokenKeyDir    string
+)
+
+func availableTokenKeyOutputs() []string {
+	return []string{"json", "pem", "jwks", "der"}
+}
+
+type ExportedTokenKey struct {
+	Kid         string `json:"kid"`
+	Alg         string `json:"alg,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+	File        string `json:"file,omitempty"`
+}
+
+func addTokenKeyOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&tokenKeyOutput, "output", "", "json", fmt.Sprintf("output format. Available formats: %v", availableTokenKeyOutputs()))
+	cmd.Flags().StringVarP(&tokenKeyDir, "dir", "", "", "write one file per key id to this directory instead of printing the keys")
+}
+
+func TokenKeyOutputValidations(output, dir string) error {
+	if !utils.Contains(availableTokenKeyOutputs(), output) {
+		return fmt.Errorf("The output format %v is not supported. Available formats: %v", output, availableTokenKeyOutputs())
+	}
+	if output == "der" && dir == "" {
+		return errors.New("DER keys are binary and can only be written to files. Use --dir to choose a directory.")
+	}
+	if output == "json" && dir != "" {
+		return errors.New("The --dir flag requires --output pem, jwks or der.")
+	}
+	return nil
+}
+
+// asymmetricKeys drops the symmetric keys, which only appear for admin
+// clients and cannot be exported as public keys.
+func asymmetricKeys(keys []uaa.JWK) []uaa.JWK {
+	asymmetric := []uaa.JWK{}
+	for _, key := range keys {
+		if !key.IsSymmetric() {
+			asymmetric = append(asymmetric, key)
+		}
+	}
+	return asymmetric
+}
+
+// keyFileName names the file for the key at index. Keys without a kid are
+// numbered so that they do not overwrite each other.
+func keyFileName(kid string, index int, extension string) string {
+	if kid == "" {
+		kid = fmt.Sprintf("token_key_%d", index)
+	}
+	return strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(kid) + extension
+}
+
+func exportedTokenKey(key uaa.JWK, file string) ExportedTokenKey {
+	fingerprint, _ := key.Fingerprint()
+	return ExportedTokenKey{Kid: key.Kid, Alg: key.Alg, Fingerprint: fingerprint, File: file}
+}
+
+// printTokenKeys prints the keys in the chosen format, or writes them to dir.
+// Printed keys are left as a plain JWKS document or PEM bundle, so their kids
+// and fingerprints are listed on details instead.
+func printTokenKeys(printer cli.Printer, details io.Writer, keys []uaa.JWK, output, dir string) error {
+	keys = asymmetricKeys(keys)
+	if len(keys) == 0 {
+		return errors.New("The UAA returned no asymmetric keys to export.")
+	}
+
+	if dir != "" {
+		return writeTokenKeys(printer, keys, output, dir)
+	}
+
+	switch output {
+	case "jwks":
+		jwks, err := toJwks(keys)
+		if err != nil {
+			return err
+		}
+		if err := printer.Print(jwks); err != nil {
+			return err
+		}
+	case "pem":
+		for _, key := range keys {
+			pemBytes, err := key.PublicKeyPEM()
+			if err != nil {
+				return err
+			}
+			log.Robots(strings.TrimSuffix(string(pemBytes), "\n"))
+		}
+	}
+
+	for _, key := range keys {
+		fingerprint, _ := key.Fingerprint()
+		fmt.Fprintf(details, "kid: %v, fingerprint: %v\n", key.Kid, fingerprint)
+	}
+	return nil
+}
+
+func toJwks(keys []uaa.JWK) (uaa.JWKS, error) {
+	jwks := uaa.JWKS{Keys: []uaa.PublicJWK{}}
+	for _, key := range keys {
+		public, err := key.Public()
+		if err != nil {
+			return uaa.JWKS{}, err
+		}
+		jwks.Keys = append(jwks.Keys, public)
+	}
+	return jwks, nil
+}
+
+func writeTokenKeys(printer cli.Printer, keys []uaa.JWK, output, dir string) error {
+	files := map[string]bool{}
+	for i, key := range keys {
+		file := filepath.Join(dir, keyFileName(key.Kid, i, "."+tokenKeyFileExtension(output)))
+		if files[file] {
+			return fmt.Errorf("More than one key would be written to %v. No keys were exported.", file)
+		}
+		files[file] = true
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("The directory %v could not be created.", dir)
+	}
+
+	exported := []ExportedTokenKey{}
+	for i, key := range keys {
+		var contents []byte
+		var err error
+		file := filepath.Join(dir, keyFileName(key.Kid, i, "."+tokenKeyFileExtension(output)))
+		switch output {
+		case "pem":
+			contents, err = key.PublicKeyPEM()
+		case "der":
+			contents, err = key.PublicKeyDER()
+		case "jwks":
+			var jwks uaa.JWKS
+			jwks, err = toJwks([]uaa.JWK{key})
+			if err == nil {
+				contents, err = json.MarshalIndent(jwks, "", "  ")
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(file, contents, 0644); err != nil {
+			return fmt.Errorf("The key could not be written to %v.", file)
+		}
+		exported = append(exported, exportedTokenKey(key, file))
+	}
+	return printer.Print(exported)
+}
+
+func tokenKeyFileExtension(output string) string {
+	if output == "jwks" {
+		return "json"
+	}
+	return output
+}
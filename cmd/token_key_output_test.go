@@ -0,0 +1,94 @@
+package cmd_test
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("TokenKeyOutput", func() {
+	const modulus = "ANJufZdrvYg5zG61x36pDq59nVUN73wSanA7hVCtN3ftT2Rm1ZTQqp5KSCfLMhaaVvJY51sHj-_i4lqUaM9CO32G93fE44VfOmPfexZeAwa8YDOikyTrhP7sZ6A4WUNeC4DlNnJF4zsznU7JxjCkASwpdL6XFwbRSzGkm6b9aM4vIewyclWehJxUGVFhnYEzIQ65qnr38feVP9enOVgQzpKsCJ-xpa8vZ_UrscoG3_IOQM6VnLrGYAyyCGeyU1JXQW_KlNmtA5eJry2Tp-MD6I34_QsNkCArHOfj8H9tXz_oc3_tVkkR252L_Lmp0TtIGfHpBmoITP9h-oKiW6NpyCc"
+
+	rsaKey := func(kid string) string {
+		return fmt.Sprintf(`{"kty": "RSA", "e": "AQAB", "use": "sig", "alg": "RS256", "kid": %q, "n": %q}`, kid, modulus)
+	}
+
+	var dir string
+
+	BeforeEach(func() {
+		config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+		dir, _ = ioutil.TempDir("", "uaa-token-keys")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("writes a JWKS file per kid to a directory", func() {
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, `{"keys": [`+rsaKey("key-1")+`,`+rsaKey("key-2")+`]}`))
+
+		session := runCommand("get-token-keys", "--output", "jwks", "--dir", dir)
+
+		Eventually(session).Should(Exit(0))
+		for _, kid := range []string{"key-1", "key-2"} {
+			contents, err := ioutil.ReadFile(filepath.Join(dir, kid+".json"))
+			Expect(err).NotTo(HaveOccurred())
+			var jwks uaa.JWKS
+			Expect(json.Unmarshal(contents, &jwks)).To(Succeed())
+			Expect(jwks.Keys).To(HaveLen(1))
+			Expect(jwks.Keys[0].Kid).To(Equal(kid))
+		}
+		Expect(session.Out).To(Say(`"file": ".*key-1.json"`))
+		Expect(session.Out).To(Say(`"file": ".*key-2.json"`))
+	})
+
+	It("numbers the files of keys without a kid", func() {
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, `{"keys": [`+rsaKey("")+`,`+rsaKey("")+`]}`))
+
+		session := runCommand("get-token-keys", "--output", "der", "--dir", dir)
+
+		Eventually(session).Should(Exit(0))
+		Expect(filepath.Join(dir, "token_key_0.der")).To(BeAnExistingFile())
+		Expect(filepath.Join(dir, "token_key_1.der")).To(BeAnExistingFile())
+	})
+
+	It("refuses to export keys whose files would collide", func() {
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, `{"keys": [`+rsaKey("a/b")+`,`+rsaKey("a_b")+`]}`))
+
+		session := runCommand("get-token-keys", "--output", "pem", "--dir", dir)
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("More than one key would be written to .*a_b.pem. No keys were exported."))
+		files, _ := ioutil.ReadDir(dir)
+		Expect(files).To(BeEmpty())
+	})
+
+	It("prints a PEM bundle and lists the kids and fingerprints separately", func() {
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, `{"keys": [`+rsaKey("key-1")+`,`+rsaKey("key-2")+`]}`))
+
+		session := runCommand("get-token-keys", "--output", "pem")
+
+		Eventually(session).Should(Exit(0))
+		rest := session.Out.Contents()
+		for i := 0; i < 2; i++ {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			Expect(block).NotTo(BeNil())
+			Expect(block.Type).To(Equal("PUBLIC KEY"))
+		}
+		Expect(rest).To(BeEmpty())
+		Expect(session.Err).To(Say("kid: key-1, fingerprint: SHA256:[0-9A-F:]{95}\n"))
+		Expect(session.Err).To(Say("kid: key-2, fingerprint: SHA256:[0-9A-F:]{95}\n"))
+	})
+})
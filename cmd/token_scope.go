@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"fmt"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+// tokenScope is separate from the scope flag of get-authcode-token and
+// get-implicit-token, which defaults to openid.
+var tokenScope string
+
+func addScopeFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&tokenScope, "scope", "", "", "comma or space separated subset of the client's scopes to request in token")
+}
+
+// requestedScope normalizes a comma or space separated list of scopes into
+// the space separated form used by OAuth.
+func requestedScope(scope string) string {
+	return strings.Join(splitScopes(scope), " ")
+}
+
+func splitScopes(scope string) []string {
+	return strings.FieldsFunc(scope, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// warnOnScopeMismatch reports when the UAA granted other scopes than those
+// requested. The UAA omits the scope of the response when it granted
+// exactly the requested scopes.
+func warnOnScopeMismatch(log cli.Logger, requested, granted string) {
+	if requested == "" || granted == "" {
+		return
+	}
+
+	grantedScopes := splitScopes(granted)
+	missing := []string{}
+	for _, s := range splitScopes(requested) {
+		if !utils.Contains(grantedScopes, s) {
+			missing = append(missing, s)
+		}
+	}
+	requestedScopes := splitScopes(requested)
+	extra := []string{}
+	for _, s := range grantedScopes {
+		if !utils.Contains(requestedScopes, s) {
+			extra = append(extra, s)
+		}
+	}
+
+	if len(missing) > 0 {
+		log.Warn(fmt.Sprintf("The UAA did not grant the requested scopes: %v", strings.Join(missing, " ")))
+	}
+	if len(extra) > 0 {
+		log.Warn(fmt.Sprintf("The UAA granted scopes that were not requested: %v", strings.Join(extra, " ")))
+	}
+}
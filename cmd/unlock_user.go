@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+func UnlockUserCmd(um uaa.UserManager, log cli.Logger, username, origin string) error {
+	user, err := um.GetByUsername(username, origin, "id,userName")
+	if err != nil {
+		return err
+	}
+
+	if err := um.Unlock(user.ID); err != nil {
+		return errors.New(describeError(err))
+	}
+
+	log.Infof("Account for user %v successfully unlocked.", utils.Emphasize(user.Username))
+	return nil
+}
+
+func UnlockUserValidations(cfg uaa.Config, args []string) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("The positional argument USERNAME must be specified.")
+	}
+	return nil
+}
+
+var unlockUserCmd = &cobra.Command{
+	Use:   "unlock-user USERNAME",
+	Short: "Unlock a user who was locked after failed logins",
+	Long:  help.UnlockUser(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(UnlockUserValidations(GetSavedConfig(), args), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		um := uaa.UserManager{GetHttpClient(), cfg}
+		err := UnlockUserCmd(um, log, args[0], origin)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(unlockUserCmd)
+	unlockUserCmd.Annotations = make(map[string]string)
+	unlockUserCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	unlockUserCmd.Flags().StringVarP(&origin, "origin", "o", "", `The identity provider in which to search. Examples: uaa, ldap, etc. `)
+	unlockUserCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain of the user")
+}
@@ -0,0 +1,70 @@
+package cmd_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("UnlockUser", func() {
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("unlock-user", "woodstock")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a username", func() {
+			session := runCommand("unlock-user")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument USERNAME must be specified."))
+		})
+	})
+
+	It("unlocks the user", func() {
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock%22+and+origin+eq+%22uaa%22&attributes=id%2CuserName"),
+			RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock", ID: "abcdef"})),
+		))
+		server.RouteToHandler("PATCH", "/Users/abcdef/status", CombineHandlers(
+			VerifyHeaderKV("Authorization", "bearer access_token"),
+			VerifyJSON(`{"locked": false}`),
+			RespondWith(http.StatusOK, `{"locked": false}`),
+		))
+
+		session := runCommand("unlock-user", "woodstock", "--origin", "uaa")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("Account for user woodstock successfully unlocked."))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("shows why the user could not be unlocked", func() {
+		server.RouteToHandler("GET", "/Users", RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock", ID: "abcdef"})))
+		server.RouteToHandler("PATCH", "/Users/abcdef/status", RespondWith(http.StatusBadRequest,
+			`{"error": "invalid_scim_resource", "error_description": "Cannot set user account to locked. User accounts only become locked through exceeding the allowed failed login attempts."}`))
+
+		session := runCommand("unlock-user", "woodstock")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("Cannot set user account to locked."))
+	})
+})
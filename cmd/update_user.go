@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"github.com/spf13/cobra"
+)
+
+// Update user flags
+var (
+	externalId   string
+	userVerified bool
+	fromFile     string
+)
+
+// UserChanges builds the body of a SCIM PATCH from the attributes in the file
+// given with --from-file, if any, and the attributes given with flags, which
+// take precedence. A nil verified leaves the verified flag alone.
+func UserChanges(fromFile, givenName, familyName, externalId string, emails, phones []string, verified *bool) (map[string]interface{}, error) {
+	changes := map[string]interface{}{}
+	if fromFile != "" {
+		content, err := ioutil.ReadFile(fromFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(content, &changes); err != nil {
+			return nil, fmt.Errorf("The file %v does not contain a JSON object of user attributes.", fromFile)
+		}
+	}
+
+	if givenName != "" || familyName != "" {
+		name := map[string]interface{}{}
+		if fileName, ok := changes["name"].(map[string]interface{}); ok {
+			name = fileName
+		}
+		if givenName != "" {
+			name["givenName"] = givenName
+		}
+		if familyName != "" {
+			name["familyName"] = familyName
+		}
+		changes["name"] = name
+	}
+	if externalId != "" {
+		changes["externalId"] = externalId
+	}
+	if len(emails) > 0 {
+		changes["emails"] = buildEmails(emails)
+	}
+	if len(phones) > 0 {
+		changes["phoneNumbers"] = buildPhones(phones)
+	}
+	if verified != nil {
+		changes["verified"] = *verified
+	}
+
+	if len(changes) == 0 {
+		return nil, errors.New("Nothing to update. Give the attributes to change with flags or --from-file.")
+	}
+	return changes, nil
+}
+
+func UpdateUserCmd(um uaa.UserManager, printer cli.Printer, username, origin string, changes map[string]interface{}) error {
+	user, err := um.GetByUsername(username, origin, "")
+	if err != nil {
+		return err
+	}
+
+	updated, err := um.PatchCurrent(user, changes)
+	if err != nil {
+		return err
+	}
+
+	return printer.Print(updated)
+}
+
+func UpdateUserValidations(cfg uaa.Config, args []string) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("The positional argument USERNAME must be specified.")
+	}
+	return nil
+}
+
+var updateUserCmd = &cobra.Command{
+	Use:   "update-user USERNAME",
+	Short: "Update attributes of a user",
+	Long:  help.UpdateUser(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(UpdateUserValidations(GetSavedConfig(), args), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+
+		var verified *bool
+		if cmd.Flags().Changed("verified") {
+			verified = &userVerified
+		}
+		changes, err := UserChanges(fromFile, givenName, familyName, externalId, emails, phoneNumbers, verified)
+		NotifyValidationErrors(err, cmd, log)
+
+		um := uaa.UserManager{GetHttpClient(), cfg}
+		err = UpdateUserCmd(um, cli.NewJsonPrinter(log), args[0], origin, changes)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(updateUserCmd)
+	updateUserCmd.Annotations = make(map[string]string)
+	updateUserCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	updateUserCmd.Flags().StringVarP(&origin, "origin", "o", "", `The identity provider in which to search. Examples: uaa, ldap, etc. `)
+	updateUserCmd.Flags().StringVarP(&familyName, "familyName", "", "", "new family name")
+	updateUserCmd.Flags().StringVarP(&givenName, "givenName", "", "", "new given name")
+	updateUserCmd.Flags().StringSliceVarP(&emails, "email", "", []string{}, "email address replacing the current ones (multiple may be specified)")
+	updateUserCmd.Flags().StringSliceVarP(&phoneNumbers, "phone", "", []string{}, "phone number replacing the current ones (multiple may be specified)")
+	updateUserCmd.Flags().StringVarP(&externalId, "externalId", "", "", "new external id")
+	updateUserCmd.Flags().BoolVarP(&userVerified, "verified", "", false, "mark the user's email address as verified, or with --verified=false as unverified")
+	updateUserCmd.Flags().StringVarP(&fromFile, "from-file", "", "", "a JSON file of SCIM user attributes to change")
+	updateUserCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain in which to update the user")
+}
@@ -0,0 +1,155 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("UpdateUser", func() {
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock%22+and+origin+eq+%22ldap%22"),
+			RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock", ID: "abcdef", Meta: &uaa.ScimMetaInfo{Version: 10}})),
+		))
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("update-user", "woodstock")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a username", func() {
+			session := runCommand("update-user")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument USERNAME must be specified."))
+		})
+
+		It("requires something to update", func() {
+			session := runCommand("update-user", "woodstock", "--origin", "ldap")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("Nothing to update. Give the attributes to change with flags or --from-file."))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	It("patches the attributes given with flags at the user's version", func() {
+		server.RouteToHandler("PATCH", "/Users/abcdef", CombineHandlers(
+			VerifyHeaderKV("Authorization", "bearer access_token"),
+			VerifyHeaderKV("If-Match", "10"),
+			VerifyJSON(`{
+				"name": {"givenName": "Woodstock", "familyName": "Bird"},
+				"emails": [{"value": "woodstock@peanuts.com", "primary": true}, {"value": "bird@peanuts.com", "primary": false}],
+				"phoneNumbers": [{"value": "555-5555"}],
+				"externalId": "cn=woodstock",
+				"verified": false
+			}`),
+			RespondWith(http.StatusOK, fixtures.MarcusUserResponse),
+		))
+
+		session := runCommand("update-user", "woodstock",
+			"--origin", "ldap",
+			"--givenName", "Woodstock",
+			"--familyName", "Bird",
+			"--email", "woodstock@peanuts.com",
+			"--email", "bird@peanuts.com",
+			"--phone", "555-5555",
+			"--externalId", "cn=woodstock",
+			"--verified=false")
+
+		Eventually(session).Should(Exit(0))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+		Expect(session.Out).To(Say(`"userName": "marcus@stoicism.com"`))
+	})
+
+	It("retries with the current version when the user changed in the meantime", func() {
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest("PATCH", "/Users/abcdef"),
+				VerifyHeaderKV("If-Match", "10"),
+				RespondWith(http.StatusPreconditionFailed, ""),
+			),
+			CombineHandlers(
+				VerifyRequest("GET", "/Users/abcdef"),
+				RespondWith(http.StatusOK, `{"id": "abcdef", "userName": "woodstock", "meta": {"version": 11}}`),
+			),
+			CombineHandlers(
+				VerifyRequest("PATCH", "/Users/abcdef"),
+				VerifyHeaderKV("If-Match", "11"),
+				VerifyJSON(`{"verified": true}`),
+				RespondWith(http.StatusOK, fixtures.MarcusUserResponse),
+			),
+		)
+
+		session := runCommand("update-user", "woodstock", "--origin", "ldap", "--verified")
+
+		Eventually(session).Should(Exit(0))
+		Expect(server.ReceivedRequests()).To(HaveLen(4))
+	})
+
+	Describe("--from-file", func() {
+		var path string
+
+		BeforeEach(func() {
+			file, _ := ioutil.TempFile("", "uaa-update-user")
+			path = file.Name()
+			file.Close()
+		})
+
+		AfterEach(func() {
+			os.Remove(path)
+		})
+
+		It("patches the attributes in the file, overridden by flags", func() {
+			ioutil.WriteFile(path, []byte(`{"displayName": "Woody", "name": {"givenName": "W", "familyName": "Bird"}}`), 0600)
+			server.RouteToHandler("PATCH", "/Users/abcdef", CombineHandlers(
+				VerifyJSON(`{"displayName": "Woody", "name": {"givenName": "Woodstock", "familyName": "Bird"}}`),
+				RespondWith(http.StatusOK, fixtures.MarcusUserResponse),
+			))
+
+			session := runCommand("update-user", "woodstock", "--origin", "ldap", "--from-file", path, "--givenName", "Woodstock")
+
+			Eventually(session).Should(Exit(0))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("rejects files which are not a JSON object", func() {
+			ioutil.WriteFile(path, []byte(`["displayName"]`), 0600)
+
+			session := runCommand("update-user", "woodstock", "--origin", "ldap", "--from-file", path)
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("does not contain a JSON object of user attributes."))
+		})
+	})
+
+	It("reports users which cannot be found", func() {
+		server.RouteToHandler("GET", "/Users", RespondWith(http.StatusOK, fixtures.PaginatedResponse()))
+
+		session := runCommand("update-user", "woodstock", "--origin", "ldap", "--verified")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("User woodstock not found in origin ldap"))
+	})
+})
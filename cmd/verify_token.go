@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"errors"
+	"github.com/spf13/cobra"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Token verification flags
+var (
+	expectedAudience string
+	expectedIssuer   string
+)
+
+// tokenKeysFor returns the token keys cached on the active target, fetching
+// and caching them again when none of them matches kid. Keys are rotated by
+// the UAA, so an unknown kid usually means the cache is stale.
+func tokenKeysFor(cfg uaa.Config, httpClient *http.Client, kid string) ([]uaa.JWK, error) {
+	target := cfg.GetActiveTarget()
+	if uaa.HasKey(target.TokenKeys, kid) {
+		return target.TokenKeys, nil
+	}
+
+	keys, err := uaa.TokenKeys(httpClient, cfg)
+	if err != nil {
+		return nil, errors.New("The token keys of the target could not be fetched.")
+	}
+	target.TokenKeys = keys
+	cfg.AddTarget(target)
+	config.WriteConfig(cfg)
+	return keys, nil
+}
+
+func defaultIssuer(target uaa.Target) string {
+	if target.Discovery != nil && target.Discovery.Issuer != "" {
+		return target.Discovery.Issuer
+	}
+	return strings.TrimRight(target.BaseUrl, "/") + "/oauth/token"
+}
+
+func VerifyTokenCmd(cfg uaa.Config, httpClient *http.Client, log cli.Logger, token, audience, issuer string) error {
+	if token == "" {
+		token = cfg.GetActiveContext().AccessToken
+	}
+	if issuer == "" {
+		issuer = defaultIssuer(cfg.GetActiveTarget())
+	}
+
+	jwt, err := uaa.ParseJwt(token)
+	if err != nil {
+		return err
+	}
+	kid, _ := jwt.Header["kid"].(string)
+	keys, err := tokenKeysFor(cfg, httpClient, kid)
+	if err != nil {
+		return err
+	}
+
+	verifier := uaa.TokenVerifier{Keys: keys, Issuer: issuer, Audience: audience, Now: time.Now()}
+	jwt, err = verifier.Verify(token)
+	if err != nil {
+		return err
+	}
+
+	exp, _ := jwt.TimeClaim("exp")
+	log.Infof("The token is valid until %v.", utils.Emphasize(exp.Format(time.RFC3339)))
+	return nil
+}
+
+func VerifyTokenValidations(cfg uaa.Config, args []string) error {
+	if err := EnsureTargetInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 && cfg.GetActiveContext().AccessToken == "" {
+		return MissingArgumentWithExplanationError("token", "There is no access_token in the active context to verify.")
+	}
+	return nil
+}
+
+var verifyTokenCmd = &cobra.Command{
+	Use:   "verify-token [TOKEN]",
+	Short: "Verify the signature and claims of a JWT using the UAA's token keys",
+	Long:  help.VerifyToken(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		NotifyValidationErrors(VerifyTokenValidations(cfg, args), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		var token string
+		if len(args) > 0 {
+			token = args[0]
+		}
+		err := VerifyTokenCmd(cfg, GetHttpClient(), log, token, expectedAudience, expectedIssuer)
+		if err != nil {
+			log.Error(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(verifyTokenCmd)
+	verifyTokenCmd.Annotations = make(map[string]string)
+	verifyTokenCmd.Annotations[TOKEN_CATEGORY] = "true"
+	verifyTokenCmd.Flags().StringVarP(&expectedAudience, "audience", "", "", "fail unless the token's aud claim includes this audience")
+	verifyTokenCmd.Flags().StringVarP(&expectedIssuer, "issuer", "", "", "expected iss claim (defaults to the token endpoint of the target)")
+}
@@ -0,0 +1,125 @@
+package cmd_test
+
+import (
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+	"net/http"
+)
+
+var _ = Describe("VerifyToken", func() {
+	encode := func(segment []byte) string {
+		return base64.RawURLEncoding.EncodeToString(segment)
+	}
+	sign := func(kid, claims string) string {
+		input := encode([]byte(`{"alg":"HS256","kid":"`+kid+`"}`)) + "." + encode([]byte(claims))
+		mac := hmac.New(sha256.New, []byte("tokenkey"))
+		mac.Write([]byte(input))
+		return input + "." + encode(mac.Sum(nil))
+	}
+	keyList := `{"keys": [{"kty": "MAC", "kid": "legacy", "alg": "HS256", "value": "tokenkey"}]}`
+
+	var validToken string
+
+	BeforeEach(func() {
+		validToken = sign("legacy", `{"iss":"`+server.URL()+`/oauth/token","exp":4102444800,"aud":["cloud_controller"]}`)
+		c := uaa.NewConfigWithServerURL(server.URL())
+		ctx := uaa.NewContextWithToken(validToken)
+		ctx.ClientId = "cf"
+		c.AddContext(ctx)
+		config.WriteConfig(c)
+	})
+
+	It("verifies the access token of the active context and caches the token keys", func() {
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, keyList))
+
+		session := runCommand("verify-token", "--audience", "cloud_controller")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("The token is valid until 2100-01-01T00:00:00Z."))
+		Expect(config.ReadConfig().GetActiveTarget().TokenKeys).To(HaveLen(1))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("uses the cached token keys without contacting the UAA", func() {
+		c := config.ReadConfig()
+		target := c.GetActiveTarget()
+		target.TokenKeys = []uaa.JWK{{Kty: "MAC", Kid: "legacy", Value: "tokenkey"}}
+		c.AddTarget(target)
+		config.WriteConfig(c)
+
+		session := runCommand("verify-token", validToken)
+
+		Eventually(session).Should(Exit(0))
+		Expect(server.ReceivedRequests()).To(HaveLen(0))
+	})
+
+	It("fetches the token keys again when the kid is not cached", func() {
+		c := config.ReadConfig()
+		target := c.GetActiveTarget()
+		target.TokenKeys = []uaa.JWK{{Kty: "MAC", Kid: "old", Value: "oldkey"}}
+		c.AddTarget(target)
+		config.WriteConfig(c)
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, keyList))
+
+		session := runCommand("verify-token")
+
+		Eventually(session).Should(Exit(0))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(config.ReadConfig().GetActiveTarget().TokenKeys[0].Kid).To(Equal("legacy"))
+	})
+
+	It("explains why a token is not valid", func() {
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, keyList))
+		expired := sign("legacy", `{"iss":"`+server.URL()+`/oauth/token","exp":1500000000}`)
+
+		session := runCommand("verify-token", expired)
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The token expired at 2017-07-14T02:40:00Z."))
+	})
+
+	It("fails when the audience does not match", func() {
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, keyList))
+
+		session := runCommand("verify-token", "--audience", "scim")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say(`The token audience \[cloud_controller\] does not include "scim".`))
+	})
+
+	It("fails when the issuer does not match", func() {
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusOK, keyList))
+
+		session := runCommand("verify-token", "--issuer", "https://other.example.com/oauth/token")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The token was issued by"))
+	})
+
+	It("reports when the token keys cannot be fetched", func() {
+		server.RouteToHandler("GET", "/token_keys", RespondWith(http.StatusInternalServerError, ""))
+		server.RouteToHandler("GET", "/token_key", RespondWith(http.StatusInternalServerError, ""))
+
+		session := runCommand("verify-token")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("The token keys of the target could not be fetched."))
+	})
+
+	It("requires a target", func() {
+		config.WriteConfig(uaa.NewConfig())
+
+		session := runCommand("verify-token", validToken)
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("You must set a target in order to use this command."))
+	})
+})
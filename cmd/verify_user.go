@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/uaa-cli/cli"
+	"code.cloudfoundry.org/uaa-cli/help"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+func VerifyUserCmd(um uaa.UserManager, log cli.Logger, username, origin string) error {
+	user, err := um.GetByUsername(username, origin, "id,userName")
+	if err != nil {
+		return err
+	}
+
+	if _, err := um.Verify(user.ID); err != nil {
+		return errors.New(describeError(err))
+	}
+
+	log.Infof("Account for user %v successfully verified.", utils.Emphasize(user.Username))
+	return nil
+}
+
+func VerifyUserValidations(cfg uaa.Config, args []string) error {
+	if err := EnsureContextInConfig(cfg); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("The positional argument USERNAME must be specified.")
+	}
+	return nil
+}
+
+var verifyUserCmd = &cobra.Command{
+	Use:   "verify-user USERNAME",
+	Short: "Mark the email address of a user as verified",
+	Long:  help.VerifyUser(),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		NotifyValidationErrors(VerifyUserValidations(GetSavedConfig(), args), cmd, log)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := GetSavedConfig()
+		um := uaa.UserManager{GetHttpClient(), cfg}
+		err := VerifyUserCmd(um, log, args[0], origin)
+		NotifyErrorsWithRetry(err, cfg, log)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(verifyUserCmd)
+	verifyUserCmd.Annotations = make(map[string]string)
+	verifyUserCmd.Annotations[USER_CRUD_CATEGORY] = "true"
+
+	verifyUserCmd.Flags().StringVarP(&origin, "origin", "o", "", `The identity provider in which to search. Examples: uaa, ldap, etc. `)
+	verifyUserCmd.Flags().StringVarP(&zoneSubdomain, "zone", "z", "", "the identity zone subdomain of the user")
+}
@@ -0,0 +1,67 @@
+package cmd_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/uaa-cli/cmd"
+	"code.cloudfoundry.org/uaa-cli/config"
+	"code.cloudfoundry.org/uaa-cli/fixtures"
+	"code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("VerifyUser", func() {
+	BeforeEach(func() {
+		cfg := uaa.NewConfigWithServerURL(server.URL())
+		cfg.AddContext(uaa.NewContextWithToken("access_token"))
+		config.WriteConfig(cfg)
+	})
+
+	Describe("Validations", func() {
+		It("requires a token in context", func() {
+			config.WriteConfig(uaa.NewConfigWithServerURL(server.URL()))
+
+			session := runCommand("verify-user", "woodstock")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say(cmd.MISSING_CONTEXT))
+		})
+
+		It("requires a username", func() {
+			session := runCommand("verify-user")
+
+			Eventually(session).Should(Exit(1))
+			Expect(session.Err).To(Say("The positional argument USERNAME must be specified."))
+		})
+	})
+
+	It("verifies the user", func() {
+		server.RouteToHandler("GET", "/Users", CombineHandlers(
+			VerifyRequest("GET", "/Users", "filter=userName+eq+%22woodstock%22+and+origin+eq+%22ldap%22&attributes=id%2CuserName"),
+			RespondWith(http.StatusOK, fixtures.PaginatedResponse(uaa.ScimUser{Username: "woodstock", ID: "abcdef"})),
+		))
+		server.RouteToHandler("GET", "/Users/abcdef/verify", CombineHandlers(
+			VerifyHeaderKV("Authorization", "bearer access_token"),
+			RespondWith(http.StatusOK, `{"id": "abcdef", "userName": "woodstock", "verified": true}`),
+		))
+
+		session := runCommand("verify-user", "woodstock", "--origin", "ldap")
+
+		Eventually(session).Should(Exit(0))
+		Expect(session.Out).To(Say("Account for user woodstock successfully verified."))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("reports users which cannot be found", func() {
+		server.RouteToHandler("GET", "/Users", RespondWith(http.StatusOK, fixtures.PaginatedResponse()))
+
+		session := runCommand("verify-user", "woodstock")
+
+		Eventually(session).Should(Exit(1))
+		Expect(session.Err).To(Say("User woodstock not found"))
+	})
+})
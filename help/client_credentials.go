@@ -33,6 +33,24 @@ BACKGROUND
   registration.  By providing your client_id and client_secret to this CLI, it
   is able to play the part of your Client application in the flow.
 
+CLIENT AUTHENTICATION
+
+  All commands which obtain tokens accept --auth-method to choose how the
+  client authenticates to the token endpoint:
+
+    client_secret_post    the client_secret is sent in the form body (default)
+    client_secret_basic   the client_secret is sent in an HTTP Basic header
+    private_key_jwt       a JWT signed with --private-key is sent as a client
+                          assertion, as described in RFC 7523
+
+  For example:
+
+    uaa get-client-credentials-token CLIENT_ID --auth-method private_key_jwt \
+      --private-key client-key.pem --key-id key-1
+
+  RSA keys sign the assertion with RS256 and P-256 EC keys with ES256. The
+  --key-id must match the kid of the public key registered for the client.
+
 WHEN SHOULD PASSWORD GRANT CLIENTS BE USED
 
   The client_credentials flow is typically used by Client applications wanting
@@ -65,5 +83,9 @@ TROUBLESHOOTING FAQ
       scopes you want to be included in the token. The "scope" field of your
       client registration is not considered by the UAA when authoring tokens for
       the client_credentials grant type.
+
+    - Use --scope to request a subset of the client's authorities. When the UAA
+      grants other scopes than those requested, the differences are reported
+      after the token is fetched.
   `
 }
@@ -5,5 +5,8 @@ func Context() string {
 such as the scopes that token contains. The uaa CLI caches these results on a
 local file so that they may be used when issuing requests that require an
 Authorization header.
+
+Use "uaa context --decode" to also see the header and claims of the access and
+id tokens in the active context.
 `
 }
@@ -0,0 +1,21 @@
+package help
+
+func DecodeToken() string {
+	return `USAGE
+
+  uaa decode-token                 decode the access token of the active context
+  uaa decode-token --id-token      decode the id token of the active context
+  uaa decode-token TOKEN
+  uaa context --decode             show the active context with decoded tokens
+
+  JWTs are base64url-decoded locally and their header and claims are printed,
+  along with the scopes and audiences they carry and the exp, iat and
+  auth_time claims as readable times. The signature of the token is not
+  checked; use verify-token for that.
+
+  Opaque tokens, such as those requested with --format opaque, carry no
+  readable claims and are printed as {"opaque": true}. Use introspect-token
+  to ask the UAA about them instead. Give --format opaque to skip decoding,
+  or --format jwt to fail when the token is not a JWT.
+`
+}
@@ -0,0 +1,17 @@
+package help
+
+func DeleteUser() string {
+	return `Deletes the user with the given username, or with --filter every user matching
+a SCIM filter.
+
+Before deleting users matching a filter, the command lists them and asks you to
+confirm by typing "yes". Use --force to skip the question, e.g. in scripts.
+The users are deleted several at a time and the result is reported for each.
+
+Examples:
+
+  uaa delete-user woodstock
+  uaa delete-user woodstock --origin ldap
+  uaa delete-user --filter 'origin eq "ldap" and active eq false'
+  uaa delete-user --filter 'userName sw "test-"' --force --concurrency 8`
+}
@@ -0,0 +1,22 @@
+package help
+
+func Discovery() string {
+	return `USAGE
+
+  uaa discovery
+
+  Fetches and prints the OpenID Connect discovery document the target
+  publishes at /.well-known/openid-configuration.
+
+ENDPOINTS
+
+  The discovery document is also fetched and saved with the target when
+  running uaa target. When the UAA is fronted by a separate login server, the
+  token, authorization, userinfo and token key requests of all commands are
+  then sent to the endpoints named in the document instead of the target.
+
+  Running this command refreshes the saved document, e.g. after the login
+  server moved. Targets whose UAA does not publish the document use the
+  UAA's own endpoints.
+`
+}
@@ -0,0 +1,27 @@
+package help
+
+func ExportUsers() string {
+	return `Writes every user of the zone to standard output, fetching them a page at a
+time until the UAA has returned all of them. Progress is reported on standard
+error, so the users can be redirected to a file.
+
+Formats:
+
+  - jsonl   one SCIM user per line (default)
+  - csv     the columns read by import-users: username, givenName, familyName,
+            emails, phones, origin, password, groups and the report columns.
+            Passwords are never exported, so fill them in before importing
+            users of the "uaa" origin.
+  - scim    a SCIM bulk request which creates the users, leaving out the
+            attributes which the UAA assigns itself
+
+Use --filter to export only some users and --attributes to fetch only some of
+their attributes. Large zones export faster with a larger --page-size, up to
+the maximum page size the UAA is configured with.
+
+Examples:
+
+  uaa export-users > users.jsonl
+  uaa export-users --filter 'origin eq "ldap"' --format csv > ldap-users.csv
+  uaa export-users --attributes userName,emails --page-size 200 > emails.jsonl`
+}
@@ -0,0 +1,18 @@
+package help
+
+func GetUser() string {
+	return `Looks up a user by username and prints it as JSON. Give --origin when users
+with the same username exist in several identity providers.
+
+With --status, a "status" object is added which shows in human form when the
+password was last changed and when the user last logged in, and the lockout
+policy of the zone. The UAA does not report whether a user is locked right now;
+uaa unlock-user can be run on any user and does no harm to unlocked ones. The
+lockout policy can only be read with the idps.read scope; without it, a warning
+is printed instead.
+
+Examples:
+
+  uaa get-user woodstock
+  uaa get-user woodstock --origin uaa --status`
+}
@@ -0,0 +1,49 @@
+package help
+
+func ImportUsers() string {
+	return `Creates every user listed in FILE, several at a time, and adds them to the
+groups named for them. The file is either CSV with a header row or JSON lines,
+chosen by its extension or with --format.
+
+CSV columns:
+
+  username, givenName, familyName, emails, phones, origin, password, groups
+
+  Columns may appear in any order and all but username and emails may be left
+  out. Separate multiple emails, phones or groups in one column with ";".
+  The origin defaults to "uaa".
+
+JSON lines:
+
+  {"username": "woodstock", "givenName": "Woodstock", "familyName": "Bird",
+   "emails": ["woodstock@peanuts.com"], "groups": ["cloud_controller.admin"]}
+
+  (one object per line)
+
+Existing users:
+
+  When a user with the same username and origin exists, --on-conflict decides
+  what happens to them:
+
+  - skip     leave the user and its group memberships as they are (default)
+  - update   replace the names, emails and phones of the user and add it to
+             the listed groups
+  - fail     report the row as failed
+
+The report:
+
+  Once every row has been handled, a report in the format of FILE is written
+  next to it (or to --report), with a status and an error for each row.
+  Importing the report again retries only the rows which failed, so fix them
+  in place and run the command with the report as FILE. Passwords are removed
+  from the rows which succeeded.
+
+  With --dry-run, nothing is changed and the report tells which users would be
+  created, updated or skipped.
+
+Examples:
+
+  uaa import-users team.csv
+  uaa import-users team.jsonl --on-conflict update --concurrency 8
+  uaa import-users team.report.csv`
+}
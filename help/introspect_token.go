@@ -0,0 +1,24 @@
+package help
+
+func IntrospectToken() string {
+	return `USAGE
+
+  uaa introspect-token --client CLIENT_ID -s CLIENT_SECRET
+  uaa introspect-token TOKEN --client CLIENT_ID -s CLIENT_SECRET --output table
+
+  Asks the UAA whether TOKEN is valid and prints whether it is active along
+  with its scopes, audience, client, user and expiry. When TOKEN is omitted,
+  the access token of the active context is introspected.
+
+  The /introspect endpoint described in RFC 7662 is used. Older UAAs which do
+  not provide it are called on /check_token instead.
+
+TROUBLESHOOTING FAQ
+
+  Scenario: You receive an error when introspecting a token.
+
+    - The client given with --client must have the uaa.resource authority.
+      Its credentials are sent with HTTP Basic authentication unless another
+      --auth-method is given.
+`
+}
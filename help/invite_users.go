@@ -0,0 +1,20 @@
+package help
+
+func InviteUsers() string {
+	return `Invites users by email address. The UAA creates an unverified user for each
+address and returns a link with which the user accepts the invitation and sets
+a password, or logs in with their identity provider. Send the links to the
+users yourself. This requires a token with the scim.invite scope.
+
+After accepting, users are sent to --redirect_uri, which must be allowed for
+the client given with --client_id, or for the client of the active context.
+
+The addresses are given as arguments, read from a file with one address per
+line with --from-file, or both. The results are printed as JSON or, with
+--output table, as tables of the users which were and were not invited.
+
+Examples:
+
+  uaa invite-users snoopy@peanuts.com woodstock@peanuts.com --redirect_uri https://app.example.com
+  uaa invite-users --from-file partners.txt --client_id app --redirect_uri https://app.example.com --output table`
+}
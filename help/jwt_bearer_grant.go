@@ -0,0 +1,33 @@
+package help
+
+func JwtBearerGrant() string {
+	return `USAGE
+
+  uaa target UAA_URL
+  uaa get-jwt-bearer-token CLIENT_ID -s CLIENT_SECRET --assertion FILE
+  cat token.jwt | uaa get-jwt-bearer-token CLIENT_ID -s CLIENT_SECRET --assertion -
+
+  After successfully running this command, the token is added to the CLI's
+  current context. Access tokens saved in the context will be attached to subsequent
+  requests when attempting to use CLI commands that hit UAA endpoints requiring
+  Authorization.
+
+BACKGROUND
+
+  The JWT bearer grant type (urn:ietf:params:oauth:grant-type:jwt-bearer) is
+  described in RFC 7523. It allows a Client to exchange a JWT issued by a
+  trusted third party, such as a workload identity provider, for an access
+  token issued by the UAA. The UAA validates the signature of the JWT against
+  the keys of an identity provider configured in the identity zone.
+
+TROUBLESHOOTING FAQ
+
+  Scenario: You are unable to get a token using get-jwt-bearer-token.
+
+    - Ensure that "urn:ietf:params:oauth:grant-type:jwt-bearer" is included in
+      the list of authorized_grant_types for your client.
+
+    - Ensure the issuer of the JWT is configured as an OIDC identity provider in
+      the targeted identity zone, and that the JWT has not expired.
+`
+}
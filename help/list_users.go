@@ -17,7 +17,10 @@ Examples:
     uaa list-users --filter 'userName eq "bob@example.com" and origin eq "ldap"'
 
   - Find all unverified users:
-    uaa list-users --filter 'verified eq false' --attributes id,userName,name,emails
+    uaa list-users --unverified --attributes id,userName,name,emails,meta
+
+  - Find unverified users who signed up before 2026:
+    uaa list-users --unverified --filter 'meta.created lt "2026-01-01T00:00:00.000Z"' --all
 
   - Find users whose username starts with "z":
     uaa list-users --filter 'userName sw "z"'
@@ -28,6 +31,9 @@ Examples:
   - See everything about a specific user, including group memberships:
     uaa list-users --filter 'userName eq "bob@example.com'
 
+  - List every user from LDAP, however many pages it takes:
+    uaa list-users --filter 'origin eq "ldap"' --attributes userName --all
+
 Keep in mind that the UAA must perform SQL joins to determine group membership so
 responses will be relatively slow when fetching results for large numbers of users
 without filtering to specific attributes of interest with the --attributes flag.
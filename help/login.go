@@ -0,0 +1,35 @@
+package help
+
+func Login() string {
+	return `USAGE
+
+  uaa target UAA_URL
+  uaa login
+  uaa login -u USERNAME --client CLIENT_ID -s CLIENT_SECRET
+  uaa login --sso --port REDIRECT_URI_PORT
+  uaa login --sso-passcode
+
+  Logs in as a user without having to choose between the get-*-token
+  commands. Each login prompt declared by the UAA's /info endpoint, such as
+  the username and password, is asked for interactively unless it is given
+  as a flag, and the answers are sent with the password grant. The resulting
+  token is saved in a new context.
+
+  By default the public "cf" client is used with the password grant. Use
+  --client and -s to log in with another client.
+
+SINGLE SIGN-ON
+
+  When the UAA is configured with external identity providers, such as SAML
+  or OIDC providers, their users cannot log in with a password. Unless a
+  username is given, uaa login then asks whether to log in with a password,
+  a browser or a one-time passcode. Use --sso to log in with a browser
+  directly, using the authorization_code grant. The --port must correspond
+  to a localhost redirect_uri of the client.
+
+  Alternatively, use --sso-passcode to log in with a one-time passcode, as
+  the cf CLI does. The URL of the login server's passcode page is printed;
+  after logging in there with a browser, enter the passcode it shows. This
+  works with clients, such as "cf", which have no localhost redirect_uri.
+`
+}
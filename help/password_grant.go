@@ -5,6 +5,8 @@ func PasswordGrant() string {
 
   uaa target UAA_URL
   uaa get-password-token CLIENT_ID -s CLIENT_SECRET -u USERNAME -p PASSWORD
+  uaa get-password-token CLIENT_ID -s CLIENT_SECRET -u USERNAME -p PASSWORD --mfa-code CODE
+  uaa get-password-token CLIENT_ID -s CLIENT_SECRET --passcode PASSCODE
 
   After successfully running this command, the token is added to the CLI's
   current context. Access tokens saved in the context will be attached to subsequent
@@ -58,6 +60,18 @@ WHEN SHOULD PASSWORD GRANT CLIENTS BE USED
 
 TROUBLESHOOTING FAQ
 
+  Scenario: The user is authenticated by an external SAML or OIDC identity provider.
+
+    - The user has no password in the UAA. Have them log in at the /passcode
+      page of the login server with a browser and pass the one-time passcode
+      shown there with --passcode, in place of --username and --password.
+
+  Scenario: The UAA requires a multi-factor authentication code for the user.
+
+    - Pass the current code from the user's authenticator app with --mfa-code.
+      When it is omitted and the UAA asks for one, the CLI prompts for it and
+      tries again.
+
   Scenario: You are unable to get a token using get-password-token.
 
     - Ensure you are using valid client_id, client_secret, username, and password.
@@ -75,6 +89,12 @@ TROUBLESHOOTING FAQ
       group memberships. The scopes in the issued token will be the intersection
       of the scopes your client requests (by listing them in the client
       registration) and the group memberships, or permissions, that the user
-      has.
+      has. Use --scope to request only some of them; scopes which were
+      requested but not granted are reported after the token is fetched.
+
+  Scenario: The user is not found, or is authenticated by the wrong identity provider.
+
+    - Use --origin to name the identity provider (e.g. uaa or ldap) which should
+      authenticate the user. It is sent to the UAA as a login_hint.
   `
 }
@@ -0,0 +1,71 @@
+package help
+
+func SetUserPassword() string {
+	return `Sets a new password for a user without knowing the current one. This requires a
+token with the password.write scope, such as one of the admin client.
+
+The new password is read from a prompt unless it is given with --password.
+With --generate, a random password is created according to the password policy
+of the zone, which is read with the idps.read scope when the token has it. The
+generated password is printed once and cannot be shown again.
+
+Examples:
+
+  uaa set-user-password woodstock
+  uaa set-user-password woodstock --origin uaa --generate`
+}
+
+func ChangePassword() string {
+	return `Changes the password of the user the active context belongs to, e.g. after
+uaa login or uaa get-password-token. The current password has to be given,
+either with --old-password or at the prompt.
+
+The new password is read from a prompt unless it is given with --password.
+With --generate, a random password is created according to the password policy
+of the zone and printed once.
+
+Examples:
+
+  uaa change-password
+  uaa change-password --generate`
+}
+
+func CreatePasswordResetCode() string {
+	return `Creates a code with which the password of a user of the uaa identity provider
+can be reset once, e.g. to send to a user who has forgotten their password. This
+requires a token with the oauth.login scope.
+
+Give --client_id and --redirect_uri to send the user to an application after
+the reset. The redirect URI must be allowed for the client.
+
+Complete the reset with uaa reset-password CODE.
+
+Examples:
+
+  uaa create-password-reset-code woodstock
+  uaa create-password-reset-code woodstock --client_id cf --redirect_uri https://console.example.com`
+}
+
+func ResetPassword() string {
+	return `Sets a new password with a code from uaa create-password-reset-code. The code
+identifies the user and can only be used once. This requires a token with the
+oauth.login scope.
+
+The new password is read from a prompt unless it is given with --password or
+generated with --generate.
+
+Examples:
+
+  uaa reset-password yCQL6a0dJr
+  uaa reset-password yCQL6a0dJr --generate`
+}
+
+func ExpirePassword() string {
+	return `Marks the password of a user as expired, so that the user has to choose a new
+one the next time they log in. The current password keeps working until then.
+
+Examples:
+
+  uaa expire-password woodstock
+  uaa expire-password woodstock --origin uaa --zone payments`
+}
@@ -0,0 +1,36 @@
+package help
+
+func RevokeToken() string {
+	return `USAGE
+
+  uaa revoke-token                          revoke the token of the active context
+  uaa revoke-token TOKEN_ID                 revoke a single token by its jti
+  uaa revoke-token --user USERNAME          revoke all tokens for a user
+  uaa revoke-token --client CLIENT_ID       revoke all tokens for a client
+  uaa revoke-token --user USERNAME --client CLIENT_ID
+  uaa revoke-token --rfc7009 [TOKEN] [--client CLIENT_ID] -s CLIENT_SECRET
+
+  Except in --rfc7009 mode, the access token of the active context is used to
+  authorize the revocation. Revoking your own token by its jti is always
+  permitted; revoking tokens for users and clients requires the tokens.revoke
+  scope, or uaa.admin.
+
+  With --rfc7009, the positional argument is the token itself rather than its
+  jti, and it defaults to the access token of the active context. The token
+  is posted to the /oauth/revoke endpoint described in RFC 7009,
+  authenticating as the client the token was issued to, which --client
+  chooses when it is not the client of the active context.
+
+  When the token of the active context is revoked, the context is marked as
+  revoked and its token will no longer be sent to the UAA. Obtain a new token
+  with one of the get-*-token commands to continue.
+
+TROUBLESHOOTING FAQ
+
+  Scenario: Revoking tokens for a client has no effect.
+
+    - The UAA only revokes tokens which it tracks. JWT access tokens are
+      only revocable when the client is configured with revocable tokens,
+      or when the token was requested with the revocable=true parameter.
+`
+}
@@ -0,0 +1,37 @@
+package help
+
+func SamlBearerGrant() string {
+	return `USAGE
+
+  uaa target UAA_URL
+  uaa get-saml-bearer-token CLIENT_ID -s CLIENT_SECRET --assertion FILE
+  uaa get-saml-bearer-token CLIENT_ID -s CLIENT_SECRET --assertion - --entity-id ENTITY_ID
+
+  After successfully running this command, the token is added to the CLI's
+  current context. Access tokens saved in the context will be attached to subsequent
+  requests when attempting to use CLI commands that hit UAA endpoints requiring
+  Authorization.
+
+BACKGROUND
+
+  The SAML 2.0 bearer grant type (urn:ietf:params:oauth:grant-type:saml2-bearer)
+  is described in RFC 7522. It allows a Client to exchange an assertion issued
+  by a trusted SAML identity provider for an access token issued by the UAA.
+
+  The assertion may be given as raw XML or as base64 encoded XML. It is sent to
+  the UAA base64url encoded, as required by the specification. The UAA accepts
+  these assertions on the token endpoint aliased to its SAML entity ID, which
+  is read from the /info endpoint unless --entity-id is given.
+
+TROUBLESHOOTING FAQ
+
+  Scenario: You are unable to get a token using get-saml-bearer-token.
+
+    - Ensure that "urn:ietf:params:oauth:grant-type:saml2-bearer" is included
+      in the list of authorized_grant_types for your client.
+
+    - Ensure the assertion's audience is the entity ID of the targeted UAA and
+      that the assertion has not expired. Assertions are usually only valid for
+      a few minutes after they are issued.
+`
+}
@@ -0,0 +1,27 @@
+package help
+
+func TokenExchange() string {
+	return `USAGE
+
+  uaa target UAA_URL
+  uaa get-password-token CLIENT_ID -s CLIENT_SECRET -u USERNAME -p PASSWORD
+  uaa exchange-token GATEWAY_CLIENT_ID -s GATEWAY_SECRET --audience orders --scope orders.read
+  uaa exchange-token GATEWAY_CLIENT_ID -s GATEWAY_SECRET --subject-token TOKEN --save
+
+  By default the access token of the active context is exchanged and the
+  resulting token response is printed. Use --save to store the exchanged token
+  in a new context instead.
+
+BACKGROUND
+
+  The token exchange grant type (urn:ietf:params:oauth:grant-type:token-exchange)
+  is described in RFC 8693. A Client presents a subject token, and optionally
+  an actor token representing the party acting on the subject's behalf, and
+  receives a new token. It is commonly used to reduce the scopes of a token
+  (down-scoping) or to target it at a different audience before calling a
+  downstream service.
+
+  Token types may be given by their short names (access_token, refresh_token,
+  id_token, jwt) or by their full URNs.
+`
+}
@@ -0,0 +1,50 @@
+package help
+
+const tokenKeyOutputs = `OUTPUT FORMATS
+
+  --output json   the keys as returned by the UAA (default)
+  --output pem    a bundle of PEM encoded public keys
+  --output jwks   a standard JWKS document (RFC 7517), without the UAA
+                  specific value field
+  --output der    DER encoded public keys; requires --dir
+
+  With pem and jwks, only the keys are printed to stdout, so that the output
+  can be redirected to a file. The kid and SHA-256 fingerprint of each key
+  are printed to stderr.
+
+  With --dir, one file per kid is written to the directory (KID.pem,
+  KID.der or KID.json) and the kid, fingerprint and path of each file are
+  printed as JSON. Keys without a kid are named token_key_N after their
+  position in the list. Nothing is written when two keys would share a
+  file name.
+
+  The public key is built from the modulus and exponent (n and e) of the
+  key, or from its PEM value when those are missing. Symmetric keys, which
+  are only visible to admin clients, are skipped.
+`
+
+func TokenKey() string {
+	return `USAGE
+
+  uaa get-token-key
+  uaa get-token-key --output pem
+  uaa get-token-key --output der --dir ./keys
+
+  Shows the key the UAA currently uses to sign JWTs.
+
+` + tokenKeyOutputs
+}
+
+func TokenKeys() string {
+	return `USAGE
+
+  uaa get-token-keys
+  uaa get-token-keys --output jwks > jwks.json
+  uaa get-token-keys --output pem --dir ./keys
+
+  Shows all keys the UAA has used to sign JWTs. Tokens signed with a
+  previous key remain valid until they expire, so verifiers need all of
+  them.
+
+` + tokenKeyOutputs
+}
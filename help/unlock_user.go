@@ -0,0 +1,12 @@
+package help
+
+func UnlockUser() string {
+	return `Unlocks a user who was locked after too many failed logins, so that they can
+log in again before the lockout period is over. Unlocking does not change the
+password; use uaa expire-password to make the user choose a new one as well.
+
+Examples:
+
+  uaa unlock-user woodstock
+  uaa unlock-user woodstock --origin uaa --zone payments`
+}
@@ -0,0 +1,28 @@
+package help
+
+func UpdateUser() string {
+	return `Changes some attributes of a user and leaves the others as they are. The user
+is looked up by username, in the identity provider given with --origin if
+usernames are not unique, and updated with a SCIM PATCH.
+
+The UAA only applies the update to the version of the user that was read. If
+someone else changed the user in the meantime, the user is read again and the
+update retried.
+
+Emails and phone numbers given with flags replace all of the user's current
+ones; the first email becomes the primary one.
+
+Any other SCIM attribute can be changed with --from-file, which takes a JSON
+object of attributes. Flags take precedence over the same attributes in the
+file.
+
+Examples:
+
+  uaa update-user woodstock --givenName Woodstock --familyName Bird
+  uaa update-user woodstock --origin ldap --email woodstock@peanuts.com --verified
+  uaa update-user woodstock --from-file woodstock.json
+
+  where woodstock.json contains, for example:
+
+  {"displayName": "Woodstock", "locale": "en-US", "active": true}`
+}
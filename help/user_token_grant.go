@@ -0,0 +1,35 @@
+package help
+
+func UserTokenGrant() string {
+	return `USAGE
+
+  uaa target UAA_URL
+  uaa get-password-token CLIENT_ID -s CLIENT_SECRET -u USERNAME -p PASSWORD
+  uaa get-user-token TARGET_CLIENT_ID
+  uaa refresh-token -s TARGET_CLIENT_SECRET
+
+  The get-user-token command uses the access token of the active context as a
+  bearer token to request a refresh token on behalf of the same user for a
+  different client, TARGET_CLIENT_ID. The refresh token is saved in a new
+  context, which becomes the active context. Use the refresh-token command
+  with the secret of the target client to obtain an access token from it.
+
+BACKGROUND
+
+  The user_token grant type is a UAA extension to OAuth2. It is typically used
+  to provision a refresh token for a service which will later act on the
+  user's behalf without requiring the user to authenticate to it directly.
+
+TROUBLESHOOTING FAQ
+
+  Scenario: You are unable to get a token using get-user-token.
+
+    - Ensure the access token in the active context was issued to a user, for
+      example with get-password-token, and that it has not expired.
+
+    - Ensure that "user_token" is included in the list of
+      authorized_grant_types for both the client of the active context and the
+      target client, and that the target client is registered for the
+      refresh_token grant type.
+`
+}
@@ -0,0 +1,27 @@
+package help
+
+func GetVerificationLink() string {
+	return `Creates a link with which a user of the uaa identity provider verifies their
+email address, e.g. to send again to a user who signed up but never verified.
+This requires a token with the scim.create scope.
+
+After verifying, the user is sent to --redirect_uri, which must be allowed for
+the client given with --client_id, or for the client of the active context.
+
+Examples:
+
+  uaa get-verification-link woodstock --redirect_uri https://app.example.com
+  uaa get-verification-link woodstock --client_id app --redirect_uri https://app.example.com`
+}
+
+func VerifyUser() string {
+	return `Marks the email address of a user as verified without sending them a link,
+e.g. when the address was confirmed some other way.
+
+Use uaa list-users --unverified to find the users whose address is not verified.
+
+Examples:
+
+  uaa verify-user woodstock
+  uaa verify-user woodstock --origin uaa --zone payments`
+}
@@ -0,0 +1,27 @@
+package help
+
+func VerifyToken() string {
+	return `USAGE
+
+  uaa verify-token
+  uaa verify-token TOKEN --audience cloud_controller
+
+  Verifies the signature of a JWT with the token keys published by the
+  targeted UAA, and checks that it has not expired and that it was issued by
+  the target. When --audience is given, the aud claim must also include it.
+  When TOKEN is omitted, the access token of the active context is verified.
+
+  The command exits with a non-zero status and prints the reason when the
+  token is not valid.
+
+  RS256, RS384 and RS512 signatures are verified with the UAA's public keys.
+  HS256, HS384 and HS512 signatures can only be verified when the symmetric
+  key is visible in the token keys, which requires an admin token.
+
+KEY CACHING
+
+  The token keys are cached with the target, so tokens can be verified
+  without contacting the UAA. When a token names a key (kid) which is not in
+  the cache, the keys are fetched again to pick up rotated keys.
+`
+}
@@ -0,0 +1,211 @@
+package uaa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type ClientAuthMethod string
+
+const (
+	CLIENT_SECRET_POST  = ClientAuthMethod("client_secret_post")
+	CLIENT_SECRET_BASIC = ClientAuthMethod("client_secret_basic")
+	PRIVATE_KEY_JWT     = ClientAuthMethod("private_key_jwt")
+)
+
+const JWT_BEARER_CLIENT_ASSERTION_TYPE = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// ClientAuthentication adds a client's credentials to a form post, either as
+// form parameters in body or as request headers. tokenUrl is the URL of the
+// token endpoint, even when the form is posted elsewhere.
+type ClientAuthentication interface {
+	Apply(tokenUrl string, body map[string]string, headers map[string]string) error
+}
+
+func clientAuthOrDefault(clientAuth ClientAuthentication, clientId, clientSecret string) ClientAuthentication {
+	if clientAuth != nil {
+		return clientAuth
+	}
+	return ClientSecretPost{ClientId: clientId, ClientSecret: clientSecret}
+}
+
+type ClientSecretPost struct {
+	ClientId     string
+	ClientSecret string
+}
+
+func (csp ClientSecretPost) Apply(tokenUrl string, body map[string]string, headers map[string]string) error {
+	body["client_id"] = csp.ClientId
+	body["client_secret"] = csp.ClientSecret
+	return nil
+}
+
+type ClientSecretBasic struct {
+	ClientId     string
+	ClientSecret string
+}
+
+func (csb ClientSecretBasic) Apply(tokenUrl string, body map[string]string, headers map[string]string) error {
+	// RFC 6749 section 2.3.1 form-encodes both values before they are joined.
+	credentials := base64.StdEncoding.EncodeToString([]byte(url.QueryEscape(csb.ClientId) + ":" + url.QueryEscape(csb.ClientSecret)))
+	headers["Authorization"] = "Basic " + credentials
+	body["client_id"] = csb.ClientId
+	return nil
+}
+
+// PrivateKeyJwt authenticates the client with a short-lived JWT signed by
+// its private key, as described in RFC 7523 section 2.2.
+type PrivateKeyJwt struct {
+	ClientId string
+	KeyId    string
+	Key      crypto.Signer
+}
+
+func NewPrivateKeyJwt(clientId, keyId string, pemBytes []byte) (PrivateKeyJwt, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return PrivateKeyJwt{}, errors.New("The private key must be PEM encoded.")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return PrivateKeyJwt{}, errors.New("The private key could not be parsed: " + err.Error())
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return PrivateKeyJwt{}, errors.New("The private key must be an RSA or EC key.")
+	}
+
+	pkj := PrivateKeyJwt{ClientId: clientId, KeyId: keyId, Key: signer}
+	if _, err := pkj.algorithm(); err != nil {
+		return PrivateKeyJwt{}, err
+	}
+	return pkj, nil
+}
+
+func (pkj PrivateKeyJwt) algorithm() (string, error) {
+	switch key := pkj.Key.(type) {
+	case *rsa.PrivateKey:
+		return "RS256", nil
+	case *ecdsa.PrivateKey:
+		if key.Curve != elliptic.P256() {
+			return "", errors.New("Only EC keys on the P-256 curve are supported for ES256 client assertions.")
+		}
+		return "ES256", nil
+	}
+	return "", errors.New("The private key must be an RSA or EC key.")
+}
+
+func (pkj PrivateKeyJwt) Apply(tokenUrl string, body map[string]string, headers map[string]string) error {
+	clientAssertion, err := pkj.clientAssertion(tokenUrl, time.Now())
+	if err != nil {
+		return err
+	}
+
+	body["client_id"] = pkj.ClientId
+	body["client_assertion_type"] = JWT_BEARER_CLIENT_ASSERTION_TYPE
+	body["client_assertion"] = clientAssertion
+	return nil
+}
+
+func (pkj PrivateKeyJwt) clientAssertion(audience string, now time.Time) (string, error) {
+	alg, err := pkj.algorithm()
+	if err != nil {
+		return "", err
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	header := map[string]string{"alg": alg, "typ": "JWT"}
+	if pkj.KeyId != "" {
+		header["kid"] = pkj.KeyId
+	}
+	claims := map[string]interface{}{
+		"iss": pkj.ClientId,
+		"sub": pkj.ClientId,
+		"aud": audience,
+		"jti": hex.EncodeToString(jti),
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+	}
+
+	headerJson, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJson, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJson) + "." + base64.RawURLEncoding.EncodeToString(claimsJson)
+	signature, err := pkj.sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (pkj PrivateKeyJwt) sign(signingInput []byte) ([]byte, error) {
+	digest := sha256.Sum256(signingInput)
+
+	switch key := pkj.Key.(type) {
+	case *rsa.PrivateKey:
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			return nil, err
+		}
+		// JWS uses the fixed-width concatenation of r and s rather than
+		// the ASN.1 encoding produced by crypto.Signer.
+		size := (key.Curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		rBytes, sBytes := r.Bytes(), s.Bytes()
+		copy(signature[size-len(rBytes):size], rBytes)
+		copy(signature[2*size-len(sBytes):], sBytes)
+		return signature, nil
+	}
+	return nil, fmt.Errorf("Unsupported private key type %T", pkj.Key)
+}
+
+// bearerAuthentication authenticates with the access token of a previously
+// established context rather than with client credentials.
+type bearerAuthentication struct {
+	context UaaContext
+}
+
+func (ba bearerAuthentication) Apply(tokenUrl string, body map[string]string, headers map[string]string) error {
+	if err := checkAccessToken(ba.context, tokenUrl); err != nil {
+		return err
+	}
+	headers["Authorization"] = "bearer " + ba.context.AccessToken
+	return nil
+}
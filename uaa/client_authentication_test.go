@@ -0,0 +1,248 @@
+package uaa_test
+
+import (
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+var _ = Describe("ClientAuthentication", func() {
+	var (
+		server *ghttp.Server
+		config Config
+		client *http.Client
+	)
+
+	const tokenResponseJson = `{
+	  "access_token" : "bc4885d950854fed9a938e96b13ca519",
+	  "token_type" : "bearer",
+	  "expires_in" : 43199,
+	  "jti" : "bc4885d950854fed9a938e96b13ca519"
+	}`
+
+	decodeSegment := func(segment string) map[string]interface{} {
+		bytes, err := base64.RawURLEncoding.DecodeString(segment)
+		Expect(err).NotTo(HaveOccurred())
+		decoded := map[string]interface{}{}
+		Expect(json.Unmarshal(bytes, &decoded)).To(Succeed())
+		return decoded
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = &http.Client{}
+		config = NewConfigWithServerURL(server.URL())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("ClientSecretPost", func() {
+		It("sends the client credentials in the form body", func() {
+			server.RouteToHandler("POST", "/oauth/token", ghttp.CombineHandlers(
+				ghttp.RespondWith(200, tokenResponseJson),
+				ghttp.VerifyFormKV("client_id", "identity"),
+				ghttp.VerifyFormKV("client_secret", "identitysecret"),
+				func(w http.ResponseWriter, req *http.Request) {
+					Expect(req.Header.Get("Authorization")).To(BeEmpty())
+				},
+			))
+
+			ccClient := ClientCredentialsClient{ClientAuth: ClientSecretPost{ClientId: "identity", ClientSecret: "identitysecret"}}
+			_, err := ccClient.RequestToken(client, config, OPAQUE)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("ClientSecretBasic", func() {
+		It("sends the client credentials in an Authorization header", func() {
+			server.RouteToHandler("POST", "/oauth/token", ghttp.CombineHandlers(
+				ghttp.RespondWith(200, tokenResponseJson),
+				ghttp.VerifyBasicAuth("identity", "identitysecret"),
+				ghttp.VerifyFormKV("client_id", "identity"),
+				ghttp.VerifyFormKV("grant_type", "refresh_token"),
+				func(w http.ResponseWriter, req *http.Request) {
+					Expect(req.Form).NotTo(HaveKey("client_secret"))
+				},
+			))
+
+			refreshClient := RefreshTokenClient{
+				ClientId:     "identity",
+				ClientSecret: "identitysecret",
+				ClientAuth:   ClientSecretBasic{ClientId: "identity", ClientSecret: "identitysecret"},
+			}
+			_, err := refreshClient.RequestToken(client, config, OPAQUE, "refresh-me")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("form-encodes the client id and secret before encoding them", func() {
+			server.RouteToHandler("POST", "/oauth/token", ghttp.CombineHandlers(
+				ghttp.RespondWith(200, tokenResponseJson),
+				ghttp.VerifyBasicAuth("app%3Aclient", "p%25ss+w%C3%B6rd%2B"),
+			))
+
+			ccClient := ClientCredentialsClient{ClientAuth: ClientSecretBasic{ClientId: "app:client", ClientSecret: "p%ss wörd+"}}
+			_, err := ccClient.RequestToken(client, config, OPAQUE)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("PrivateKeyJwt", func() {
+		It("requires a PEM encoded key", func() {
+			_, err := NewPrivateKeyJwt("identity", "key-1", []byte("not a key"))
+
+			Expect(err).To(MatchError("The private key must be PEM encoded."))
+		})
+
+		It("rejects EC keys on curves other than P-256", func() {
+			key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+			der, _ := x509.MarshalECPrivateKey(key)
+			pemBytes := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+
+			_, err := NewPrivateKeyJwt("identity", "key-1", pemBytes)
+
+			Expect(err).To(MatchError("Only EC keys on the P-256 curve are supported for ES256 client assertions."))
+		})
+
+		It("sends an RS256 client assertion signed with a PKCS1 RSA key", func() {
+			key, _ := rsa.GenerateKey(rand.Reader, 2048)
+			pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+			clientAuth, err := NewPrivateKeyJwt("identity", "key-1", pemBytes)
+			Expect(err).NotTo(HaveOccurred())
+
+			var clientAssertion string
+			server.RouteToHandler("POST", "/oauth/token", ghttp.CombineHandlers(
+				ghttp.RespondWith(200, tokenResponseJson),
+				ghttp.VerifyFormKV("client_id", "identity"),
+				ghttp.VerifyFormKV("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"),
+				ghttp.VerifyFormKV("grant_type", "client_credentials"),
+				func(w http.ResponseWriter, req *http.Request) {
+					Expect(req.Form).NotTo(HaveKey("client_secret"))
+					clientAssertion = req.Form.Get("client_assertion")
+				},
+			))
+
+			ccClient := ClientCredentialsClient{ClientId: "identity", ClientAuth: clientAuth}
+			_, err = ccClient.RequestToken(client, config, OPAQUE)
+			Expect(err).NotTo(HaveOccurred())
+
+			segments := strings.Split(clientAssertion, ".")
+			Expect(segments).To(HaveLen(3))
+
+			header := decodeSegment(segments[0])
+			Expect(header["alg"]).To(Equal("RS256"))
+			Expect(header["kid"]).To(Equal("key-1"))
+
+			claims := decodeSegment(segments[1])
+			Expect(claims["iss"]).To(Equal("identity"))
+			Expect(claims["sub"]).To(Equal("identity"))
+			Expect(claims["aud"]).To(Equal(server.URL() + "/oauth/token"))
+			Expect(claims["jti"]).NotTo(BeEmpty())
+			Expect(claims["exp"].(float64) - claims["iat"].(float64)).To(Equal(float64(300)))
+
+			signature, _ := base64.RawURLEncoding.DecodeString(segments[2])
+			digest := sha256.Sum256([]byte(segments[0] + "." + segments[1]))
+			Expect(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature)).To(Succeed())
+		})
+
+		It("sends an ES256 client assertion signed with a PKCS8 EC key", func() {
+			key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			der, _ := x509.MarshalPKCS8PrivateKey(key)
+			pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+			clientAuth, err := NewPrivateKeyJwt("identity", "", pemBytes)
+			Expect(err).NotTo(HaveOccurred())
+
+			var clientAssertion string
+			server.RouteToHandler("POST", "/oauth/token", ghttp.CombineHandlers(
+				ghttp.RespondWith(200, tokenResponseJson),
+				func(w http.ResponseWriter, req *http.Request) {
+					req.ParseForm()
+					clientAssertion = req.Form.Get("client_assertion")
+				},
+			))
+
+			passwordClient := ResourceOwnerPasswordClient{ClientId: "identity", Username: "woodstock", Password: "secret", ClientAuth: clientAuth}
+			_, err = passwordClient.RequestToken(client, config, OPAQUE)
+			Expect(err).NotTo(HaveOccurred())
+
+			segments := strings.Split(clientAssertion, ".")
+			Expect(segments).To(HaveLen(3))
+
+			header := decodeSegment(segments[0])
+			Expect(header["alg"]).To(Equal("ES256"))
+			Expect(header).NotTo(HaveKey("kid"))
+
+			signature, _ := base64.RawURLEncoding.DecodeString(segments[2])
+			Expect(signature).To(HaveLen(64))
+			r := new(big.Int).SetBytes(signature[:32])
+			s := new(big.Int).SetBytes(signature[32:])
+			digest := sha256.Sum256([]byte(segments[0] + "." + segments[1]))
+			Expect(ecdsa.Verify(&key.PublicKey, digest[:], r, s)).To(BeTrue())
+		})
+
+		It("uses the aliased token endpoint as the audience for SAML bearer requests", func() {
+			key, _ := rsa.GenerateKey(rand.Reader, 2048)
+			pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+			clientAuth, _ := NewPrivateKeyJwt("identity", "key-1", pemBytes)
+
+			var clientAssertion string
+			server.RouteToHandler("POST", "/oauth/token/alias/uaa.example.com", ghttp.CombineHandlers(
+				ghttp.RespondWith(200, tokenResponseJson),
+				func(w http.ResponseWriter, req *http.Request) {
+					req.ParseForm()
+					clientAssertion = req.Form.Get("client_assertion")
+				},
+			))
+
+			samlClient := Saml2BearerClient{ClientId: "identity", EntityId: "uaa.example.com", ClientAuth: clientAuth}
+			_, err := samlClient.RequestToken(client, config, OPAQUE, "<saml2:Assertion/>")
+			Expect(err).NotTo(HaveOccurred())
+
+			claims := decodeSegment(strings.Split(clientAssertion, ".")[1])
+			Expect(claims["aud"]).To(Equal(server.URL() + "/oauth/token/alias/uaa.example.com"))
+		})
+
+		It("uses the token endpoint as the audience for requests to other endpoints", func() {
+			key, _ := rsa.GenerateKey(rand.Reader, 2048)
+			pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+			clientAuth, _ := NewPrivateKeyJwt("identity", "key-1", pemBytes)
+
+			var clientAssertion string
+			server.RouteToHandler("POST", "/oauth/revoke", ghttp.CombineHandlers(
+				ghttp.RespondWith(200, ""),
+				func(w http.ResponseWriter, req *http.Request) {
+					req.ParseForm()
+					clientAssertion = req.Form.Get("client_assertion")
+				},
+			))
+
+			err := RevocationClient{ClientId: "identity", ClientAuth: clientAuth}.Revoke(client, config, "some-token", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			claims := decodeSegment(strings.Split(clientAssertion, ".")[1])
+			Expect(claims["aud"]).To(Equal(server.URL() + "/oauth/token"))
+		})
+	})
+})
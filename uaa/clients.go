@@ -182,40 +182,14 @@ func (cm *ClientManager) ChangeSecret(clientId string, newSecret string) error {
 	return err
 }
 
-func getResultPage(cm *ClientManager, startIndex, count int) (PaginatedClientList, error) {
-	query := fmt.Sprintf("startIndex=%v&count=%v", startIndex, count)
-	if startIndex == 0 {
-		query = ""
-	}
-
-	bytes, err := AuthenticatedRequester{}.Get(cm.HttpClient, cm.Config, "/oauth/clients", query)
-	if err != nil {
-		return PaginatedClientList{}, err
-	}
-
-	clientList := PaginatedClientList{}
-	err = json.Unmarshal(bytes, &clientList)
-	if err != nil {
-		return PaginatedClientList{}, parseError("/oauth/clients", bytes)
-	}
-	return clientList, nil
-}
-
 func (cm *ClientManager) List() ([]UaaClient, error) {
-	results, err := getResultPage(cm, 0, 0)
-	if err != nil {
-		return []UaaClient{}, err
+	clientList := []UaaClient{}
+	clients := cm.ListAll(ListOptions{})
+	for clients.Next() {
+		clientList = append(clientList, clients.Client())
 	}
-
-	clientList := results.Resources
-	startIndex, count := results.StartIndex, results.ItemsPerPage
-	for results.TotalResults > len(clientList) {
-		startIndex += count
-		newResults, err := getResultPage(cm, startIndex, count)
-		if err != nil {
-			return []UaaClient{}, err
-		}
-		clientList = append(clientList, newResults.Resources...)
+	if clients.Err() != nil {
+		return []UaaClient{}, clients.Err()
 	}
 
 	return clientList, nil
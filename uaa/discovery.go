@@ -0,0 +1,73 @@
+package uaa
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// OpenIdConfiguration is the OpenID Connect discovery document published at
+// /.well-known/openid-configuration.
+type OpenIdConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint,omitempty"`
+	JwksUri                           string   `json:"jwks_uri"`
+	EndSessionEndpoint                string   `json:"end_session_endpoint,omitempty"`
+	ScopesSupported                   []string `json:"scopes_supported,omitempty"`
+	ResponseTypesSupported            []string `json:"response_types_supported,omitempty"`
+	SubjectTypesSupported             []string `json:"subject_types_supported,omitempty"`
+	IdTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported,omitempty"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported,omitempty"`
+	ClaimsSupported                   []string `json:"claims_supported,omitempty"`
+	ClaimTypesSupported               []string `json:"claim_types_supported,omitempty"`
+	ServiceDocumentation              string   `json:"service_documentation,omitempty"`
+	UiLocalesSupported                []string `json:"ui_locales_supported,omitempty"`
+}
+
+func Discover(client *http.Client, config Config) (OpenIdConfiguration, error) {
+	body, err := UnauthenticatedRequester{}.Get(client, config, "/.well-known/openid-configuration", "")
+	if err != nil {
+		return OpenIdConfiguration{}, err
+	}
+
+	discovery := OpenIdConfiguration{}
+	err = json.Unmarshal(body, &discovery)
+	if err != nil {
+		return OpenIdConfiguration{}, parseError("/.well-known/openid-configuration", body)
+	}
+
+	return discovery, nil
+}
+
+// The endpoints of a target default to the paths the UAA serves them on, and
+// are replaced by the absolute URLs of the discovery document cached with the
+// target, if any. Those may be on a separate login server host.
+
+func (t Target) TokenEndpoint() string {
+	if t.Discovery != nil && t.Discovery.TokenEndpoint != "" {
+		return t.Discovery.TokenEndpoint
+	}
+	return "/oauth/token"
+}
+
+func (t Target) AuthorizationEndpoint() string {
+	if t.Discovery != nil && t.Discovery.AuthorizationEndpoint != "" {
+		return t.Discovery.AuthorizationEndpoint
+	}
+	return "/oauth/authorize"
+}
+
+func (t Target) UserinfoEndpoint() string {
+	if t.Discovery != nil && t.Discovery.UserinfoEndpoint != "" {
+		return t.Discovery.UserinfoEndpoint
+	}
+	return "/userinfo"
+}
+
+func (t Target) JwksUri() string {
+	if t.Discovery != nil && t.Discovery.JwksUri != "" {
+		return t.Discovery.JwksUri
+	}
+	return "/token_keys"
+}
@@ -0,0 +1,90 @@
+package uaa_test
+
+import (
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"net/http"
+)
+
+var _ = Describe("Discovery", func() {
+	var (
+		server      *ghttp.Server
+		loginServer *ghttp.Server
+		config      Config
+		client      *http.Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		loginServer = ghttp.NewServer()
+		client = &http.Client{}
+		config = NewConfigWithServerURL(server.URL())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		loginServer.Close()
+	})
+
+	It("fetches the OpenID Connect discovery document", func() {
+		server.RouteToHandler("GET", "/.well-known/openid-configuration", ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/.well-known/openid-configuration"),
+			ghttp.RespondWith(http.StatusOK, `{
+			  "issuer": "https://login.example.com/oauth/token",
+			  "authorization_endpoint": "https://login.example.com/oauth/authorize",
+			  "token_endpoint": "https://login.example.com/oauth/token",
+			  "userinfo_endpoint": "https://login.example.com/userinfo",
+			  "jwks_uri": "https://login.example.com/token_keys"
+			}`),
+		))
+
+		discovery, err := Discover(client, config)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(discovery.Issuer).To(Equal("https://login.example.com/oauth/token"))
+		Expect(discovery.JwksUri).To(Equal("https://login.example.com/token_keys"))
+	})
+
+	It("returns an error when the target has no discovery document", func() {
+		server.RouteToHandler("GET", "/.well-known/openid-configuration", ghttp.RespondWith(http.StatusNotFound, ""))
+
+		_, err := Discover(client, config)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("target endpoints", func() {
+		It("default to the UAA's own paths", func() {
+			target := config.GetActiveTarget()
+
+			Expect(target.TokenEndpoint()).To(Equal("/oauth/token"))
+			Expect(target.AuthorizationEndpoint()).To(Equal("/oauth/authorize"))
+			Expect(target.UserinfoEndpoint()).To(Equal("/userinfo"))
+			Expect(target.JwksUri()).To(Equal("/token_keys"))
+		})
+
+		It("are taken from the cached discovery document", func() {
+			target := config.GetActiveTarget()
+			target.Discovery = &OpenIdConfiguration{
+				UserinfoEndpoint: loginServer.URL() + "/userinfo",
+				JwksUri:          loginServer.URL() + "/token_keys",
+			}
+			config.AddTarget(target)
+			config.AddContext(NewContextWithToken("access_token"))
+			loginServer.RouteToHandler("GET", "/userinfo", ghttp.RespondWith(http.StatusOK, `{"user_name": "woodstock"}`))
+			loginServer.RouteToHandler("GET", "/token_keys", ghttp.RespondWith(http.StatusOK, `{"keys": [{"kty": "RSA", "kid": "key-1"}]}`))
+
+			userinfo, err := Me(client, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userinfo.Username).To(Equal("woodstock"))
+
+			keys, err := TokenKeys(client, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys[0].Kid).To(Equal("key-1"))
+
+			Expect(server.ReceivedRequests()).To(HaveLen(0))
+		})
+	})
+})
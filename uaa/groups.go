@@ -5,8 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
 )
 
 type ScimGroupMember struct {
@@ -90,25 +88,7 @@ func (gm GroupManager) GetByName(name, attributes string) (ScimGroup, error) {
 func (gm GroupManager) List(filter, sortBy, attributes string, sortOrder ScimSortOrder, startIdx, count int) (PaginatedGroupList, error) {
 	endpoint := "/Groups"
 
-	query := url.Values{}
-	if filter != "" {
-		query.Add("filter", filter)
-	}
-	if attributes != "" {
-		query.Add("attributes", attributes)
-	}
-	if sortBy != "" {
-		query.Add("sortBy", sortBy)
-	}
-	if count != 0 {
-		query.Add("count", strconv.Itoa(count))
-	}
-	if startIdx != 0 {
-		query.Add("startIndex", strconv.Itoa(startIdx))
-	}
-	if sortOrder != "" {
-		query.Add("sortOrder", string(sortOrder))
-	}
+	query := scimQuery(filter, sortBy, attributes, sortOrder, startIdx, count)
 
 	bytes, err := AuthenticatedRequester{}.Get(gm.HttpClient, gm.Config, endpoint, query.Encode())
 	if err != nil {
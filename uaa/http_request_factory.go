@@ -144,11 +144,22 @@ func (urf UnauthenticatedRequestFactory) PatchJson(target Target, path string, q
 	return req, nil
 }
 
+// checkAccessToken returns an error when the context has no access token
+// which can be used to call url.
+func checkAccessToken(ctx UaaContext, url string) error {
+	if ctx.AccessToken == "" {
+		return errors.New("An access token is required to call " + url)
+	}
+	if ctx.Revoked {
+		return errors.New("The access token in the active context has been revoked. Obtain a new token to call " + url)
+	}
+	return nil
+}
+
 func addAuthorization(req *http.Request, ctx UaaContext) (*http.Request, error) {
-	accessToken := ctx.AccessToken
-	req.Header.Add("Authorization", "bearer "+accessToken)
-	if accessToken == "" {
-		return nil, errors.New("An access token is required to call " + req.URL.String())
+	req.Header.Add("Authorization", "bearer "+ctx.AccessToken)
+	if err := checkAccessToken(ctx, req.URL.String()); err != nil {
+		return nil, err
 	}
 
 	return req, nil
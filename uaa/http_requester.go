@@ -65,7 +65,7 @@ func doAndRead(req *http.Request, client *http.Client, config Config) ([]byte, e
 	}
 
 	if !is2XX(resp.StatusCode) {
-		return []byte{}, requestError(req.URL.String())
+		return []byte{}, RequestError{Url: req.URL.String(), StatusCode: resp.StatusCode, ErrorResponse: bytes}
 	}
 	return bytes, nil
 }
@@ -64,6 +64,17 @@ var _ = Describe("HttpGetter", func() {
 				Expect(err).NotTo(BeNil())
 				Expect(err.Error()).To(ContainSubstring("An unknown error occurred while calling"))
 			})
+
+			It("includes the status and response body in the error", func() {
+				server.RouteToHandler("GET", "/testPath", ghttp.RespondWith(400, `{"error":"invalid_request"}`))
+
+				_, err := UnauthenticatedRequester{}.Get(client, config, "/testPath", "")
+
+				requestErr, ok := err.(RequestError)
+				Expect(ok).To(BeTrue())
+				Expect(requestErr.StatusCode).To(Equal(400))
+				Expect(string(requestErr.ErrorResponse)).To(Equal(`{"error":"invalid_request"}`))
+			})
 		})
 
 		Describe("Delete", func() {
@@ -0,0 +1,53 @@
+package uaa
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type identityProvider struct {
+	OriginKey string `json:"originKey"`
+	Config    struct {
+		PasswordPolicy *PasswordPolicy `json:"passwordPolicy"`
+		LockoutPolicy  *LockoutPolicy  `json:"lockoutPolicy"`
+	} `json:"config"`
+}
+
+// getUaaIdentityProvider reads the configuration of the zone's internal
+// identity provider, whose origin is "uaa".
+func getUaaIdentityProvider(client *http.Client, config Config) (identityProvider, error) {
+	path := "/identity-providers"
+	bytes, err := AuthenticatedRequester{}.Get(client, config, path, "rawConfig=true")
+	if err != nil {
+		return identityProvider{}, err
+	}
+
+	providers := []identityProvider{}
+	if err := json.Unmarshal(bytes, &providers); err != nil {
+		return identityProvider{}, parseError(path, bytes)
+	}
+	for _, provider := range providers {
+		if provider.OriginKey == "uaa" {
+			return provider, nil
+		}
+	}
+	return identityProvider{}, nil
+}
+
+// LockoutPolicy decides when users of the "uaa" identity provider are locked
+// after failed logins. Values below zero mean the UAA's defaults apply.
+type LockoutPolicy struct {
+	LockoutPeriodSeconds int `json:"lockoutPeriodSeconds"`
+	LockoutAfterFailures int `json:"lockoutAfterFailures"`
+	CountFailuresWithin  int `json:"countFailuresWithin"`
+}
+
+// GetLockoutPolicy reads the lockout policy of the zone, which requires the
+// idps.read scope. It returns nil when the zone has none of its own.
+func GetLockoutPolicy(client *http.Client, config Config) (*LockoutPolicy, error) {
+	provider, err := getUaaIdentityProvider(client, config)
+	if err != nil {
+		return nil, err
+	}
+	return provider.Config.LockoutPolicy, nil
+}
@@ -3,6 +3,7 @@ package uaa
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 )
 
 type UaaInfo struct {
@@ -27,6 +28,16 @@ type uaaLinks struct {
 	Login          string `json:"login"`
 }
 
+// PasscodeUrl is the page of the login server where users of external
+// identity providers obtain a one-time passcode.
+func (info UaaInfo) PasscodeUrl(baseUrl string) string {
+	loginUrl := info.Links.Login
+	if loginUrl == "" {
+		loginUrl = baseUrl
+	}
+	return strings.TrimRight(loginUrl, "/") + "/passcode"
+}
+
 func Info(client *http.Client, config Config) (UaaInfo, error) {
 	bytes, err := UnauthenticatedRequester{}.Get(client, config, "info", "")
 	if err != nil {
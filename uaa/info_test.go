@@ -9,6 +9,19 @@ import (
 )
 
 var _ = Describe("Info", func() {
+	Describe("PasscodeUrl", func() {
+		It("points to the passcode page of the login server", func() {
+			info := UaaInfo{}
+			info.Links.Login = "https://login.run.pivotal.io"
+
+			Expect(info.PasscodeUrl("https://uaa.run.pivotal.io")).To(Equal("https://login.run.pivotal.io/passcode"))
+		})
+
+		It("falls back to the target when there is no login link", func() {
+			Expect(UaaInfo{}.PasscodeUrl("https://uaa.run.pivotal.io/")).To(Equal("https://uaa.run.pivotal.io/passcode"))
+		})
+	})
+
 	var (
 		server *ghttp.Server
 		config Config
@@ -0,0 +1,48 @@
+package uaa
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// Invitation is the outcome of inviting one email address. Successful
+// invitations carry the id of the new user and the link to send them.
+type Invitation struct {
+	Email        string `json:"email"`
+	UserId       string `json:"userId,omitempty"`
+	Origin       string `json:"origin,omitempty"`
+	Success      bool   `json:"success"`
+	ErrorCode    string `json:"errorCode,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	InviteLink   string `json:"inviteLink,omitempty"`
+}
+
+type InvitationResult struct {
+	NewInvites    []Invitation `json:"new_invites"`
+	FailedInvites []Invitation `json:"failed_invites"`
+}
+
+// Invite creates users for the email addresses and returns a link for each
+// with which they accept the invitation. After accepting, users are sent to
+// the redirect URI, which must be allowed for the client. An empty clientId
+// stands for the client of the active context's token.
+func (um UserManager) Invite(emails []string, clientId, redirectUri string) (InvitationResult, error) {
+	path := "/invite_users"
+	query := url.Values{}
+	if clientId != "" {
+		query.Add("client_id", clientId)
+	}
+	query.Add("redirect_uri", redirectUri)
+
+	body := map[string][]string{"emails": emails}
+	bytes, err := AuthenticatedRequester{}.PostJson(um.HttpClient, um.Config, path, query.Encode(), body)
+	if err != nil {
+		return InvitationResult{}, err
+	}
+
+	result := InvitationResult{}
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		return InvitationResult{}, parseError(path, bytes)
+	}
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package uaa_test
+
+import (
+	"net/http"
+
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Invitations", func() {
+	var (
+		uaaServer *ghttp.Server
+		um        UserManager
+	)
+
+	BeforeEach(func() {
+		uaaServer = ghttp.NewServer()
+		config := NewConfigWithServerURL(uaaServer.URL())
+		config.AddContext(NewContextWithToken("access_token"))
+		um = UserManager{&http.Client{}, config}
+	})
+
+	AfterEach(func() {
+		uaaServer.Close()
+	})
+
+	It("invites the email addresses", func() {
+		uaaServer.RouteToHandler("POST", "/invite_users", ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", "/invite_users", "client_id=app&redirect_uri=https%3A%2F%2Fapp.example.com"),
+			ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+			ghttp.VerifyJSON(`{"emails": ["snoopy@peanuts.com", "woodstock@peanuts.com"]}`),
+			ghttp.RespondWith(http.StatusOK, `{
+				"new_invites": [{"email": "snoopy@peanuts.com", "userId": "abcdef", "origin": "uaa", "success": true, "inviteLink": "https://login.example.com/invitations/accept?code=abc"}],
+				"failed_invites": [{"email": "woodstock@peanuts.com", "success": false, "errorCode": "user.ambiguous", "errorMessage": "User email is ambiguous"}]
+			}`),
+		))
+
+		result, err := um.Invite([]string{"snoopy@peanuts.com", "woodstock@peanuts.com"}, "app", "https://app.example.com")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.NewInvites).To(Equal([]Invitation{
+			{Email: "snoopy@peanuts.com", UserId: "abcdef", Origin: "uaa", Success: true, InviteLink: "https://login.example.com/invitations/accept?code=abc"},
+		}))
+		Expect(result.FailedInvites).To(Equal([]Invitation{
+			{Email: "woodstock@peanuts.com", ErrorCode: "user.ambiguous", ErrorMessage: "User email is ambiguous"},
+		}))
+	})
+
+	It("leaves out the client id when it is not given", func() {
+		uaaServer.RouteToHandler("POST", "/invite_users", ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", "/invite_users", "redirect_uri=https%3A%2F%2Fapp.example.com"),
+			ghttp.RespondWith(http.StatusOK, `{"new_invites": [], "failed_invites": []}`),
+		))
+
+		_, err := um.Invite([]string{"snoopy@peanuts.com"}, "", "https://app.example.com")
+
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns an error when the request is refused", func() {
+		uaaServer.RouteToHandler("POST", "/invite_users", ghttp.RespondWith(http.StatusForbidden, `{"error": "insufficient_scope"}`))
+
+		_, err := um.Invite([]string{"snoopy@peanuts.com"}, "", "https://app.example.com")
+
+		Expect(err).To(HaveOccurred())
+	})
+})
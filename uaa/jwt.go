@@ -0,0 +1,118 @@
+package uaa
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Jwt is a JSON Web Token split into its parts. Parsing a token does not
+// verify its signature.
+type Jwt struct {
+	Header       map[string]interface{}
+	Claims       map[string]interface{}
+	SigningInput string
+	Signature    []byte
+}
+
+func ParseJwt(token string) (Jwt, error) {
+	notJwtError := errors.New("The token is not a JWT. Opaque tokens cannot be decoded locally.")
+
+	segments := strings.Split(strings.TrimSpace(token), ".")
+	if len(segments) != 3 {
+		return Jwt{}, notJwtError
+	}
+
+	header, err := decodeJwtSegment(segments[0])
+	if err != nil {
+		return Jwt{}, notJwtError
+	}
+	claims, err := decodeJwtSegment(segments[1])
+	if err != nil {
+		return Jwt{}, notJwtError
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segments[2], "="))
+	if err != nil {
+		return Jwt{}, notJwtError
+	}
+
+	return Jwt{
+		Header:       header,
+		Claims:       claims,
+		SigningInput: segments[0] + "." + segments[1],
+		Signature:    signature,
+	}, nil
+}
+
+func decodeJwtSegment(segment string) (map[string]interface{}, error) {
+	segmentBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, err
+	}
+
+	// Numbers are kept as json.Number so that large timestamps and IDs
+	// are displayed exactly as they were issued.
+	decoder := json.NewDecoder(bytes.NewReader(segmentBytes))
+	decoder.UseNumber()
+	decoded := map[string]interface{}{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func (j Jwt) StringClaim(name string) string {
+	value, _ := j.Claims[name].(string)
+	return value
+}
+
+// ListClaim returns a claim which may be either a single space-delimited
+// string or an array of strings, such as scope or aud.
+func (j Jwt) ListClaim(name string) []string {
+	switch value := j.Claims[name].(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		list := []string{}
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func (j Jwt) Scopes() []string {
+	return j.ListClaim("scope")
+}
+
+func (j Jwt) Audience() []string {
+	return j.ListClaim("aud")
+}
+
+// TimeClaim returns a NumericDate claim such as exp, iat or auth_time.
+func (j Jwt) TimeClaim(name string) (time.Time, bool) {
+	var seconds int64
+	switch value := j.Claims[name].(type) {
+	case json.Number:
+		parsed, err := value.Int64()
+		if err != nil {
+			floatValue, err := value.Float64()
+			if err != nil {
+				return time.Time{}, false
+			}
+			parsed = int64(floatValue)
+		}
+		seconds = parsed
+	case float64:
+		seconds = int64(value)
+	default:
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0).UTC(), true
+}
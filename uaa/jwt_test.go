@@ -0,0 +1,66 @@
+package uaa_test
+
+import (
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+
+	"encoding/base64"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+var _ = Describe("Jwt", func() {
+	encode := func(segment string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(segment))
+	}
+
+	var token string
+
+	BeforeEach(func() {
+		token = encode(`{"alg":"RS256","kid":"key-1","typ":"JWT"}`) + "." +
+			encode(`{"sub":"abcdef","scope":["openid","scim.read"],"aud":"scim","exp":1500000000,"iat":1499956800,"jti":"bc4885d9"}`) + "." +
+			encode("signature")
+	})
+
+	It("decodes the header and claims", func() {
+		jwt, err := ParseJwt(token)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jwt.Header["alg"]).To(Equal("RS256"))
+		Expect(jwt.Header["kid"]).To(Equal("key-1"))
+		Expect(jwt.StringClaim("sub")).To(Equal("abcdef"))
+		Expect(jwt.SigningInput).To(Equal(token[:len(token)-len(encode("signature"))-1]))
+		Expect(jwt.Signature).To(Equal([]byte("signature")))
+	})
+
+	It("lists scopes and audiences given as arrays or strings", func() {
+		jwt, _ := ParseJwt(token)
+
+		Expect(jwt.Scopes()).To(Equal([]string{"openid", "scim.read"}))
+		Expect(jwt.Audience()).To(Equal([]string{"scim"}))
+		Expect(jwt.ListClaim("missing")).To(BeNil())
+	})
+
+	It("reads timestamps", func() {
+		jwt, _ := ParseJwt(token)
+
+		exp, ok := jwt.TimeClaim("exp")
+		Expect(ok).To(BeTrue())
+		Expect(exp).To(Equal(time.Date(2017, 7, 14, 2, 40, 0, 0, time.UTC)))
+
+		_, ok = jwt.TimeClaim("auth_time")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("rejects opaque tokens", func() {
+		_, err := ParseJwt("bc4885d950854fed9a938e96b13ca519")
+
+		Expect(err).To(MatchError("The token is not a JWT. Opaque tokens cannot be decoded locally."))
+	})
+
+	It("rejects tokens whose segments are not JSON", func() {
+		_, err := ParseJwt("a.b.c")
+
+		Expect(err).To(HaveOccurred())
+	})
+})
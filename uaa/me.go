@@ -18,7 +18,8 @@ type Userinfo struct {
 }
 
 func Me(client *http.Client, config Config) (Userinfo, error) {
-	body, err := AuthenticatedRequester{}.Get(client, config, "/userinfo", "scheme=openid")
+	path := config.GetActiveTarget().UserinfoEndpoint()
+	body, err := AuthenticatedRequester{}.Get(client, config, path, "scheme=openid")
 	if err != nil {
 		return Userinfo{}, err
 	}
@@ -26,7 +27,7 @@ func Me(client *http.Client, config Config) (Userinfo, error) {
 	info := Userinfo{}
 	err = json.Unmarshal(body, &info)
 	if err != nil {
-		return Userinfo{}, parseError("/userinfo", body)
+		return Userinfo{}, parseError(path, body)
 	}
 
 	return info, nil
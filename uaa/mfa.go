@@ -0,0 +1,46 @@
+package uaa
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// MfaError is returned by the password grant when the user has to provide a
+// multi-factor authentication code, or when the code given was rejected.
+type MfaError struct {
+	Required    bool
+	Description string
+}
+
+func (me MfaError) Error() string {
+	if me.Required {
+		return "The UAA requires a multi-factor authentication code for this user. Use --mfa-code to provide it."
+	}
+	return "The multi-factor authentication code was rejected: " + me.Description
+}
+
+type oauthErrorResponse struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// asMfaError recognizes token endpoint errors which concern multi-factor
+// authentication by their error_description, since the UAA reports them
+// with the generic invalid_grant and unauthorized error codes.
+func asMfaError(err error, mfaCode string) error {
+	requestErr, ok := err.(RequestError)
+	if !ok {
+		return err
+	}
+
+	response := oauthErrorResponse{}
+	if json.Unmarshal(requestErr.ErrorResponse, &response) != nil {
+		return err
+	}
+	description := strings.ToLower(response.Description)
+	if !strings.Contains(description, "multi-factor") && !strings.Contains(description, "mfa") {
+		return err
+	}
+
+	return MfaError{Required: mfaCode == "", Description: response.Description}
+}
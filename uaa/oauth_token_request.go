@@ -1,12 +1,22 @@
 package uaa
 
 import (
+	"bytes"
+	"code.cloudfoundry.org/uaa-cli/utils"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
-func postToOAuthToken(httpClient *http.Client, config Config, body map[string]string) (TokenResponse, error) {
-	bytes, err := UnauthenticatedRequester{}.PostForm(httpClient, config, "/oauth/token", "", body)
+func postToOAuthToken(clientAuth ClientAuthentication, httpClient *http.Client, config Config, body map[string]string) (TokenResponse, error) {
+	return postToTokenEndpoint(clientAuth, httpClient, config, config.GetActiveTarget().TokenEndpoint(), body)
+}
+
+func postToTokenEndpoint(clientAuth ClientAuthentication, httpClient *http.Client, config Config, path string, body map[string]string) (TokenResponse, error) {
+	bytes, err := postForm(clientAuth, httpClient, config, path, path, body)
 	if err != nil {
 		return TokenResponse{}, err
 	}
@@ -14,85 +24,310 @@ func postToOAuthToken(httpClient *http.Client, config Config, body map[string]st
 	tokenResponse := TokenResponse{}
 	err = json.Unmarshal(bytes, &tokenResponse)
 	if err != nil {
-		return TokenResponse{}, parseError("/oauth/token", bytes)
+		return TokenResponse{}, parseError(path, bytes)
 	}
 
 	return tokenResponse, nil
 }
 
+// postFormWithClientAuth posts a form with client credentials to an endpoint
+// other than the token endpoint, such as /introspect or /oauth/revoke. Client
+// assertions are still addressed to the token endpoint, which is the audience
+// the UAA accepts for all of them.
+func postFormWithClientAuth(clientAuth ClientAuthentication, httpClient *http.Client, config Config, path string, body map[string]string) ([]byte, error) {
+	return postForm(clientAuth, httpClient, config, path, config.GetActiveTarget().TokenEndpoint(), body)
+}
+
+func postForm(clientAuth ClientAuthentication, httpClient *http.Client, config Config, path, tokenPath string, body map[string]string) ([]byte, error) {
+	target := config.GetActiveTarget()
+	tokenUrl, err := utils.BuildUrl(target.BaseUrl, tokenPath)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	headers := map[string]string{}
+	err = clientAuth.Apply(tokenUrl.String(), body, headers)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	data := mapToUrlValues(body)
+	req, err := UnauthenticatedRequestFactory{}.PostForm(target, path, "", &data)
+	if err != nil {
+		return []byte{}, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	addZoneSwitchHeader(req, &config)
+
+	return doAndRead(req, httpClient, config)
+}
+
+// LoginHint builds the login_hint the UAA uses to pick the identity provider
+// which authenticates the user.
+func LoginHint(origin string) string {
+	hint, _ := json.Marshal(map[string]string{"origin": origin})
+	return string(hint)
+}
+
+// addOptionalParams adds the optional scope and login_hint parameters to a
+// token request body when they were given.
+func addOptionalParams(body map[string]string, scope, origin string) {
+	if scope != "" {
+		body["scope"] = scope
+	}
+	if origin != "" {
+		body["login_hint"] = LoginHint(origin)
+	}
+}
+
 type ClientCredentialsClient struct {
 	ClientId     string
 	ClientSecret string
+	ClientAuth   ClientAuthentication
+	Scope        string
 }
 
 func (cc ClientCredentialsClient) RequestToken(httpClient *http.Client, config Config, format TokenFormat) (TokenResponse, error) {
 	body := map[string]string{
 		"grant_type":    string(CLIENT_CREDENTIALS),
-		"client_id":     cc.ClientId,
-		"client_secret": cc.ClientSecret,
 		"token_format":  string(format),
 		"response_type": "token",
 	}
+	addOptionalParams(body, cc.Scope, "")
 
-	return postToOAuthToken(httpClient, config, body)
+	return postToOAuthToken(clientAuthOrDefault(cc.ClientAuth, cc.ClientId, cc.ClientSecret), httpClient, config, body)
 }
 
 type ResourceOwnerPasswordClient struct {
 	ClientId     string
 	ClientSecret string
+	ClientAuth   ClientAuthentication
 	Username     string
 	Password     string
+	Passcode     string
+	MfaCode      string
+	Scope        string
+	Origin       string
+	// Prompts holds the answers to any other login prompts declared by the
+	// UAA's /info endpoint, keyed by prompt name.
+	Prompts map[string]string
 }
 
 func (rop ResourceOwnerPasswordClient) RequestToken(httpClient *http.Client, config Config, format TokenFormat) (TokenResponse, error) {
 	body := map[string]string{
 		"grant_type":    string(PASSWORD),
-		"client_id":     rop.ClientId,
-		"client_secret": rop.ClientSecret,
-		"username":      rop.Username,
-		"password":      rop.Password,
 		"token_format":  string(format),
 		"response_type": "token",
 	}
+	// A one-time passcode identifies the user on its own, in place of their
+	// username and password.
+	if rop.Passcode != "" {
+		body["passcode"] = rop.Passcode
+	} else {
+		body["username"] = rop.Username
+		body["password"] = rop.Password
+	}
+	if rop.MfaCode != "" {
+		body["mfaCode"] = rop.MfaCode
+	}
+	for name, value := range rop.Prompts {
+		if _, ok := body[name]; !ok {
+			body[name] = value
+		}
+	}
+	addOptionalParams(body, rop.Scope, rop.Origin)
 
-	return postToOAuthToken(httpClient, config, body)
+	tokenResponse, err := postToOAuthToken(clientAuthOrDefault(rop.ClientAuth, rop.ClientId, rop.ClientSecret), httpClient, config, body)
+	if err != nil {
+		return TokenResponse{}, asMfaError(err, rop.MfaCode)
+	}
+	return tokenResponse, nil
 }
 
 type AuthorizationCodeClient struct {
 	ClientId     string
 	ClientSecret string
+	ClientAuth   ClientAuthentication
 }
 
 func (acc AuthorizationCodeClient) RequestToken(httpClient *http.Client, config Config, format TokenFormat, code string, redirectUri string) (TokenResponse, error) {
 	body := map[string]string{
 		"grant_type":    string(AUTHCODE),
-		"client_id":     acc.ClientId,
-		"client_secret": acc.ClientSecret,
 		"token_format":  string(format),
 		"response_type": "token",
 		"redirect_uri":  redirectUri,
 		"code":          code,
 	}
 
-	return postToOAuthToken(httpClient, config, body)
+	return postToOAuthToken(clientAuthOrDefault(acc.ClientAuth, acc.ClientId, acc.ClientSecret), httpClient, config, body)
 }
 
 type RefreshTokenClient struct {
 	ClientId     string
 	ClientSecret string
+	ClientAuth   ClientAuthentication
+	Scope        string
 }
 
 func (rc RefreshTokenClient) RequestToken(httpClient *http.Client, config Config, format TokenFormat, refreshToken string) (TokenResponse, error) {
 	body := map[string]string{
 		"grant_type":    string(REFRESH_TOKEN),
 		"refresh_token": refreshToken,
-		"client_id":     rc.ClientId,
-		"client_secret": rc.ClientSecret,
 		"token_format":  string(format),
 		"response_type": "token",
 	}
+	addOptionalParams(body, rc.Scope, "")
+
+	return postToOAuthToken(clientAuthOrDefault(rc.ClientAuth, rc.ClientId, rc.ClientSecret), httpClient, config, body)
+}
+
+type JwtBearerClient struct {
+	ClientId     string
+	ClientSecret string
+	ClientAuth   ClientAuthentication
+	Scope        string
+}
+
+func (jbc JwtBearerClient) RequestToken(httpClient *http.Client, config Config, format TokenFormat, assertion string) (TokenResponse, error) {
+	body := map[string]string{
+		"grant_type":    string(JWT_BEARER),
+		"assertion":     assertion,
+		"token_format":  string(format),
+		"response_type": "token",
+	}
+	addOptionalParams(body, jbc.Scope, "")
+
+	return postToOAuthToken(clientAuthOrDefault(jbc.ClientAuth, jbc.ClientId, jbc.ClientSecret), httpClient, config, body)
+}
+
+type Saml2BearerClient struct {
+	ClientId     string
+	ClientSecret string
+	ClientAuth   ClientAuthentication
+	EntityId     string
+	Scope        string
+}
+
+func (sbc Saml2BearerClient) RequestToken(httpClient *http.Client, config Config, format TokenFormat, assertion string) (TokenResponse, error) {
+	encodedAssertion, err := encodeSamlAssertion(assertion)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	body := map[string]string{
+		"grant_type":    string(SAML2_BEARER),
+		"assertion":     encodedAssertion,
+		"token_format":  string(format),
+		"response_type": "token",
+	}
+	addOptionalParams(body, sbc.Scope, "")
+
+	// The UAA only accepts SAML bearer assertions on the token endpoint
+	// aliased to the entity ID of the service provider they were issued for.
+	path := config.GetActiveTarget().TokenEndpoint()
+	if sbc.EntityId != "" {
+		path = strings.TrimRight(path, "/") + "/alias/" + url.PathEscape(sbc.EntityId)
+	}
+
+	return postToTokenEndpoint(clientAuthOrDefault(sbc.ClientAuth, sbc.ClientId, sbc.ClientSecret), httpClient, config, path, body)
+}
+
+// RFC 7522 requires the assertion to be base64url encoded without padding
+// or line breaks. Assertions may be given either as raw XML or already
+// encoded with any base64 alphabet.
+func encodeSamlAssertion(assertion string) (string, error) {
+	assertion = strings.TrimSpace(assertion)
+	if strings.HasPrefix(assertion, "<") {
+		return base64.RawURLEncoding.EncodeToString([]byte(assertion)), nil
+	}
+
+	compacted := strings.Join(strings.Fields(assertion), "")
+	encodings := []*base64.Encoding{
+		base64.RawURLEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.StdEncoding,
+	}
+	for _, encoding := range encodings {
+		decoded, err := encoding.DecodeString(compacted)
+		if err == nil && bytes.HasPrefix(bytes.TrimSpace(decoded), []byte("<")) {
+			return base64.RawURLEncoding.EncodeToString(decoded), nil
+		}
+	}
+
+	return "", errors.New("The SAML assertion must be XML or base64 encoded XML.")
+}
 
-	return postToOAuthToken(httpClient, config, body)
+// UserTokenClient uses the user_token grant to obtain a refresh token for
+// ClientId on behalf of the user whose access token is in the active context.
+type UserTokenClient struct {
+	ClientId string
+	Scope    string
+}
+
+func (utc UserTokenClient) RequestToken(httpClient *http.Client, config Config, format TokenFormat) (TokenResponse, error) {
+	body := map[string]string{
+		"grant_type":    string(USER_TOKEN),
+		"client_id":     utc.ClientId,
+		"token_format":  string(format),
+		"response_type": "token",
+	}
+	addOptionalParams(body, utc.Scope, "")
+
+	return postToTokenEndpoint(bearerAuthentication{context: config.GetActiveContext()}, httpClient, config, config.GetActiveTarget().TokenEndpoint(), body)
+}
+
+type TokenExchangeClient struct {
+	ClientId     string
+	ClientSecret string
+	ClientAuth   ClientAuthentication
+}
+
+type TokenExchangeRequest struct {
+	SubjectToken       string
+	SubjectTokenType   TokenType
+	ActorToken         string
+	ActorTokenType     TokenType
+	RequestedTokenType TokenType
+	Audience           string
+	Scope              string
+}
+
+func (tec TokenExchangeClient) RequestToken(httpClient *http.Client, config Config, format TokenFormat, request TokenExchangeRequest) (TokenResponse, error) {
+	if request.SubjectToken == "" {
+		return TokenResponse{}, errors.New("A subject token is required for the token exchange grant type.")
+	}
+	subjectTokenType := request.SubjectTokenType
+	if subjectTokenType == "" {
+		subjectTokenType = ACCESS_TOKEN_TYPE
+	}
+
+	body := map[string]string{
+		"grant_type":         string(TOKEN_EXCHANGE),
+		"subject_token":      request.SubjectToken,
+		"subject_token_type": string(subjectTokenType),
+		"token_format":       string(format),
+		"response_type":      "token",
+	}
+	if request.ActorToken != "" {
+		actorTokenType := request.ActorTokenType
+		if actorTokenType == "" {
+			actorTokenType = ACCESS_TOKEN_TYPE
+		}
+		body["actor_token"] = request.ActorToken
+		body["actor_token_type"] = string(actorTokenType)
+	}
+	if request.RequestedTokenType != "" {
+		body["requested_token_type"] = string(request.RequestedTokenType)
+	}
+	if request.Audience != "" {
+		body["audience"] = request.Audience
+	}
+	addOptionalParams(body, request.Scope, "")
+
+	return postToOAuthToken(clientAuthOrDefault(tec.ClientAuth, tec.ClientId, tec.ClientSecret), httpClient, config, body)
 }
 
 type TokenFormat string
@@ -110,14 +345,34 @@ const (
 	IMPLICIT           = GrantType("implicit")
 	PASSWORD           = GrantType("password")
 	CLIENT_CREDENTIALS = GrantType("client_credentials")
+	JWT_BEARER         = GrantType("urn:ietf:params:oauth:grant-type:jwt-bearer")
+	SAML2_BEARER       = GrantType("urn:ietf:params:oauth:grant-type:saml2-bearer")
+	USER_TOKEN         = GrantType("user_token")
+	TOKEN_EXCHANGE     = GrantType("urn:ietf:params:oauth:grant-type:token-exchange")
+)
+
+// TokenType identifies the kind of token given to or issued by the token
+// exchange grant, as registered in RFC 8693.
+type TokenType string
+
+const (
+	ACCESS_TOKEN_TYPE  = TokenType("urn:ietf:params:oauth:token-type:access_token")
+	REFRESH_TOKEN_TYPE = TokenType("urn:ietf:params:oauth:token-type:refresh_token")
+	ID_TOKEN_TYPE      = TokenType("urn:ietf:params:oauth:token-type:id_token")
+	JWT_TOKEN_TYPE     = TokenType("urn:ietf:params:oauth:token-type:jwt")
 )
 
 type TokenResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	IdToken      string `json:"id_token"`
-	TokenType    string `json:"token_type"`
-	ExpiresIn    int32  `json:"expires_in"`
-	Scope        string `json:"scope"`
-	JTI          string `json:"jti"`
+	AccessToken     string    `json:"access_token"`
+	RefreshToken    string    `json:"refresh_token"`
+	IdToken         string    `json:"id_token"`
+	TokenType       string    `json:"token_type"`
+	ExpiresIn       int32     `json:"expires_in"`
+	Scope           string    `json:"scope"`
+	JTI             string    `json:"jti"`
+	IssuedTokenType TokenType `json:"issued_token_type,omitempty"`
+
+	// Revoked is never sent by the UAA. It is set locally when a token saved
+	// in a context is revoked so that it is no longer presented to the UAA.
+	Revoked bool `json:"revoked,omitempty"`
 }
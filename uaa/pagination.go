@@ -0,0 +1,240 @@
+package uaa
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListOptions select and order the resources walked by the ListAll methods.
+// A PageSize of 0 leaves the size of the pages to the UAA. Prefetch is the
+// number of pages fetched in parallel ahead of the one being read.
+type ListOptions struct {
+	Filter     string
+	SortBy     string
+	Attributes string
+	SortOrder  ScimSortOrder
+	PageSize   int
+	Prefetch   int
+}
+
+func scimQuery(filter, sortBy, attributes string, sortOrder ScimSortOrder, startIdx, count int) url.Values {
+	query := url.Values{}
+	if filter != "" {
+		query.Add("filter", filter)
+	}
+	if attributes != "" {
+		query.Add("attributes", attributes)
+	}
+	if sortBy != "" {
+		query.Add("sortBy", sortBy)
+	}
+	if count != 0 {
+		query.Add("count", strconv.Itoa(count))
+	}
+	if startIdx != 0 {
+		query.Add("startIndex", strconv.Itoa(startIdx))
+	}
+	if sortOrder != "" {
+		query.Add("sortOrder", string(sortOrder))
+	}
+	return query
+}
+
+type rawPage struct {
+	Resources    []json.RawMessage `json:"resources"`
+	TotalResults int               `json:"totalResults"`
+}
+
+// pageFetcher returns the resources of the page beginning at the 1-based
+// startIndex, together with the total number of results.
+type pageFetcher func(startIndex, count int) ([]json.RawMessage, int, error)
+
+func scimPageFetcher(client *http.Client, config Config, endpoint string, options ListOptions) pageFetcher {
+	return func(startIndex, count int) ([]json.RawMessage, int, error) {
+		query := scimQuery(options.Filter, options.SortBy, options.Attributes, options.SortOrder, startIndex, count)
+		bytes, err := AuthenticatedRequester{}.Get(client, config, endpoint, query.Encode())
+		if err != nil {
+			return nil, 0, err
+		}
+
+		page := rawPage{}
+		if err := json.Unmarshal(bytes, &page); err != nil {
+			return nil, 0, parseError(endpoint, bytes)
+		}
+		return page.Resources, page.TotalResults, nil
+	}
+}
+
+type pageResult struct {
+	resources    []json.RawMessage
+	totalResults int
+	err          error
+}
+
+// Cursor walks every page of a listing one resource at a time. Pages are
+// fetched when the previous one has been read, or ahead of time when
+// prefetching. The typed cursors of each resource wrap it.
+type Cursor struct {
+	fetch    pageFetcher
+	pageSize int
+	prefetch int
+
+	started      bool
+	done         bool
+	nextIndex    int
+	totalResults int
+	pending      []chan pageResult
+	resources    []json.RawMessage
+	current      json.RawMessage
+	err          error
+}
+
+func newCursor(fetch pageFetcher, pageSize, prefetch int) *Cursor {
+	return &Cursor{fetch: fetch, pageSize: pageSize, prefetch: prefetch, nextIndex: 1}
+}
+
+func (c *Cursor) schedule() {
+	result := make(chan pageResult, 1)
+	startIndex, count := c.nextIndex, c.pageSize
+	go func() {
+		resources, totalResults, err := c.fetch(startIndex, count)
+		result <- pageResult{resources, totalResults, err}
+	}()
+	c.pending = append(c.pending, result)
+	c.nextIndex += c.pageSize
+}
+
+func (c *Cursor) morePages() bool {
+	return c.nextIndex <= c.totalResults
+}
+
+// Next advances to the next resource, fetching pages as needed. It returns
+// false when all resources have been read or an error occurred.
+func (c *Cursor) Next() bool {
+	for len(c.resources) == 0 {
+		if c.done || c.err != nil {
+			return false
+		}
+		if len(c.pending) == 0 {
+			if c.started && !c.morePages() {
+				return false
+			}
+			c.schedule()
+		}
+
+		result := <-c.pending[0]
+		c.pending = c.pending[1:]
+		if result.err != nil {
+			c.err = result.err
+			return false
+		}
+		if len(result.resources) == 0 {
+			c.done = true
+			return false
+		}
+		c.totalResults = result.totalResults
+		c.resources = result.resources
+
+		// The UAA may return smaller pages than asked for, so the first page
+		// decides where the following ones begin.
+		if !c.started {
+			c.started = true
+			if c.pageSize == 0 || len(result.resources) < c.pageSize {
+				c.pageSize = len(result.resources)
+			}
+			c.nextIndex = 1 + len(result.resources)
+		}
+		for len(c.pending) < c.prefetch && c.morePages() {
+			c.schedule()
+		}
+	}
+
+	c.current, c.resources = c.resources[0], c.resources[1:]
+	return true
+}
+
+// Decode unmarshals the current resource into v.
+func (c *Cursor) Decode(v interface{}) error {
+	return json.Unmarshal(c.current, v)
+}
+
+// TotalResults is the number of resources the UAA reported for the listing,
+// known once Next has been called.
+func (c *Cursor) TotalResults() int {
+	return c.totalResults
+}
+
+// Err returns the error which stopped the cursor, if any.
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+func (c *Cursor) decodeNext(v interface{}) bool {
+	if !c.Next() {
+		return false
+	}
+	if err := c.Decode(v); err != nil {
+		c.err = err
+		return false
+	}
+	return true
+}
+
+type UserCursor struct {
+	*Cursor
+	user ScimUser
+}
+
+func (uc *UserCursor) Next() bool {
+	uc.user = ScimUser{}
+	return uc.decodeNext(&uc.user)
+}
+
+func (uc *UserCursor) User() ScimUser {
+	return uc.user
+}
+
+type GroupCursor struct {
+	*Cursor
+	group ScimGroup
+}
+
+func (gc *GroupCursor) Next() bool {
+	gc.group = ScimGroup{}
+	return gc.decodeNext(&gc.group)
+}
+
+func (gc *GroupCursor) Group() ScimGroup {
+	return gc.group
+}
+
+type ClientCursor struct {
+	*Cursor
+	client UaaClient
+}
+
+func (cc *ClientCursor) Next() bool {
+	cc.client = UaaClient{}
+	return cc.decodeNext(&cc.client)
+}
+
+func (cc *ClientCursor) Client() UaaClient {
+	return cc.client
+}
+
+func (um UserManager) ListAll(options ListOptions) *UserCursor {
+	fetch := scimPageFetcher(um.HttpClient, um.Config, "/Users", options)
+	return &UserCursor{Cursor: newCursor(fetch, options.PageSize, options.Prefetch)}
+}
+
+func (gm GroupManager) ListAll(options ListOptions) *GroupCursor {
+	fetch := scimPageFetcher(gm.HttpClient, gm.Config, "/Groups", options)
+	return &GroupCursor{Cursor: newCursor(fetch, options.PageSize, options.Prefetch)}
+}
+
+func (cm *ClientManager) ListAll(options ListOptions) *ClientCursor {
+	fetch := scimPageFetcher(cm.HttpClient, cm.Config, "/oauth/clients", options)
+	return &ClientCursor{Cursor: newCursor(fetch, options.PageSize, options.Prefetch)}
+}
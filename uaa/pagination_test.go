@@ -0,0 +1,230 @@
+package uaa_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Pagination", func() {
+	var (
+		uaaServer *ghttp.Server
+		config    Config
+	)
+
+	// pagedUsers answers /Users with pages of the given usernames, honoring
+	// startIndex and count but never returning more than maxPage at once.
+	pagedUsers := func(maxPage int, usernames ...string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			startIndex, _ := strconv.Atoi(req.URL.Query().Get("startIndex"))
+			count, _ := strconv.Atoi(req.URL.Query().Get("count"))
+			if count == 0 || count > maxPage {
+				count = maxPage
+			}
+			resources := []string{}
+			for i := startIndex - 1; i < len(usernames) && i < startIndex-1+count; i++ {
+				resources = append(resources, fmt.Sprintf(`{"userName": "%v"}`, usernames[i]))
+			}
+			fmt.Fprintf(w, `{"resources": [%v], "startIndex": %v, "itemsPerPage": %v, "totalResults": %v}`,
+				strings.Join(resources, ","), startIndex, len(resources), len(usernames))
+		}
+	}
+
+	usernames := func(cursor *UserCursor) []string {
+		names := []string{}
+		for cursor.Next() {
+			names = append(names, cursor.User().Username)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		uaaServer = ghttp.NewServer()
+		config = NewConfigWithServerURL(uaaServer.URL())
+		config.AddContext(NewContextWithToken("access_token"))
+	})
+
+	AfterEach(func() {
+		uaaServer.Close()
+	})
+
+	Describe("UserManager#ListAll", func() {
+		It("walks every page with the given options", func() {
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/Users", "count=2&filter=origin+eq+%22uaa%22&sortBy=userName&sortOrder=ascending&attributes=userName&startIndex=1"),
+					ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+					pagedUsers(2, "linus", "lucy", "snoopy"),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/Users", "count=2&filter=origin+eq+%22uaa%22&sortBy=userName&sortOrder=ascending&attributes=userName&startIndex=3"),
+					pagedUsers(2, "linus", "lucy", "snoopy"),
+				),
+			)
+
+			um := UserManager{&http.Client{}, config}
+			cursor := um.ListAll(ListOptions{
+				Filter:     `origin eq "uaa"`,
+				SortBy:     "userName",
+				SortOrder:  SORT_ASCENDING,
+				Attributes: "userName",
+				PageSize:   2,
+			})
+
+			Expect(usernames(cursor)).To(Equal([]string{"linus", "lucy", "snoopy"}))
+			Expect(cursor.Err()).NotTo(HaveOccurred())
+			Expect(cursor.TotalResults()).To(Equal(3))
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("fetches nothing until Next is called", func() {
+			um := UserManager{&http.Client{}, config}
+			um.ListAll(ListOptions{})
+
+			Expect(uaaServer.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("follows the page size of the UAA when it returns smaller pages", func() {
+			uaaServer.RouteToHandler("GET", "/Users", pagedUsers(2, "a", "b", "c", "d", "e"))
+
+			um := UserManager{&http.Client{}, config}
+			cursor := um.ListAll(ListOptions{PageSize: 500})
+
+			Expect(usernames(cursor)).To(Equal([]string{"a", "b", "c", "d", "e"}))
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(3))
+			Expect(uaaServer.ReceivedRequests()[1].URL.Query().Get("startIndex")).To(Equal("3"))
+			Expect(uaaServer.ReceivedRequests()[1].URL.Query().Get("count")).To(Equal("2"))
+		})
+
+		It("stops when a page comes back empty", func() {
+			uaaServer.RouteToHandler("GET", "/Users", ghttp.RespondWith(http.StatusOK, `{"resources": [], "totalResults": 10}`))
+
+			um := UserManager{&http.Client{}, config}
+			cursor := um.ListAll(ListOptions{})
+
+			Expect(cursor.Next()).To(BeFalse())
+			Expect(cursor.Next()).To(BeFalse())
+			Expect(cursor.Err()).NotTo(HaveOccurred())
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("reports errors through Err", func() {
+			uaaServer.AppendHandlers(
+				pagedUsers(1, "linus", "lucy"),
+				ghttp.RespondWith(http.StatusInternalServerError, ""),
+			)
+
+			um := UserManager{&http.Client{}, config}
+			cursor := um.ListAll(ListOptions{PageSize: 1})
+
+			Expect(usernames(cursor)).To(Equal([]string{"linus"}))
+			Expect(cursor.Err()).To(HaveOccurred())
+		})
+
+		It("reports unparseable pages through Err", func() {
+			uaaServer.RouteToHandler("GET", "/Users", ghttp.RespondWith(http.StatusOK, "{garbage}"))
+
+			um := UserManager{&http.Client{}, config}
+			cursor := um.ListAll(ListOptions{})
+
+			Expect(cursor.Next()).To(BeFalse())
+			Expect(cursor.Err()).To(MatchError(ContainSubstring("An unknown error occurred while parsing response from /Users")))
+		})
+
+		It("fetches pages ahead in parallel when prefetching", func() {
+			var mutex sync.Mutex
+			inFlight, maxInFlight := 0, 0
+			release := make(chan bool)
+			handler := pagedUsers(1, "a", "b", "c", "d")
+			uaaServer.RouteToHandler("GET", "/Users", func(w http.ResponseWriter, req *http.Request) {
+				mutex.Lock()
+				inFlight++
+				if inFlight > maxInFlight {
+					maxInFlight = inFlight
+				}
+				mutex.Unlock()
+
+				if req.URL.Query().Get("startIndex") != "1" {
+					<-release
+				}
+				handler(w, req)
+
+				mutex.Lock()
+				inFlight--
+				mutex.Unlock()
+			})
+
+			um := UserManager{&http.Client{}, config}
+			cursor := um.ListAll(ListOptions{PageSize: 1, Prefetch: 3})
+
+			Expect(cursor.Next()).To(BeTrue())
+			Eventually(func() int {
+				mutex.Lock()
+				defer mutex.Unlock()
+				return maxInFlight
+			}).Should(Equal(3))
+			close(release)
+
+			Expect(cursor.User().Username).To(Equal("a"))
+			Expect(usernames(cursor)).To(Equal([]string{"b", "c", "d"}))
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(4))
+		})
+	})
+
+	Describe("GroupManager#ListAll", func() {
+		It("walks every page of groups", func() {
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/Groups", "count=1&startIndex=1"),
+					ghttp.RespondWith(http.StatusOK, `{"resources": [{"displayName": "uaa.admin"}], "totalResults": 2}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/Groups", "count=1&startIndex=2"),
+					ghttp.RespondWith(http.StatusOK, `{"resources": [{"displayName": "openid"}], "totalResults": 2}`),
+				),
+			)
+
+			gm := GroupManager{&http.Client{}, config}
+			cursor := gm.ListAll(ListOptions{PageSize: 1})
+
+			names := []string{}
+			for cursor.Next() {
+				names = append(names, cursor.Group().DisplayName)
+			}
+			Expect(names).To(Equal([]string{"uaa.admin", "openid"}))
+			Expect(cursor.Err()).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("ClientManager#ListAll", func() {
+		It("walks every page of clients", func() {
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/oauth/clients", "startIndex=1"),
+					ghttp.RespondWith(http.StatusOK, `{"resources": [{"client_id": "cf"}], "totalResults": 2}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/oauth/clients", "count=1&startIndex=2"),
+					ghttp.RespondWith(http.StatusOK, `{"resources": [{"client_id": "admin"}], "totalResults": 2}`),
+				),
+			)
+
+			cm := &ClientManager{&http.Client{}, config}
+			cursor := cm.ListAll(ListOptions{})
+
+			ids := []string{}
+			for cursor.Next() {
+				ids = append(ids, cursor.Client().ClientId)
+			}
+			Expect(ids).To(Equal([]string{"cf", "admin"}))
+			Expect(cursor.Err()).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,213 @@
+package uaa
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/uaa-cli/utils"
+)
+
+type passwordChange struct {
+	Password    string `json:"password"`
+	OldPassword string `json:"oldPassword,omitempty"`
+}
+
+// SetPassword changes the password of a user. Administrators may leave
+// oldPassword empty; users changing their own password must provide it.
+func (um UserManager) SetPassword(userID, password, oldPassword string) error {
+	url := "/Users/" + userID + "/password"
+	body := passwordChange{Password: password, OldPassword: oldPassword}
+	_, err := AuthenticatedRequester{}.PutJson(um.HttpClient, um.Config, url, "", body)
+	return err
+}
+
+// ExpirePassword makes the user change their password at the next login.
+func (um UserManager) ExpirePassword(userID string) error {
+	required := true
+	return um.SetStatus(userID, UserStatus{PasswordChangeRequired: &required})
+}
+
+type PasswordResetCode struct {
+	Code   string `json:"code"`
+	UserId string `json:"user_id"`
+}
+
+// CreatePasswordResetCode requests a code with which the password of a user of
+// the "uaa" identity provider can be reset once. The client id and redirect URI
+// are optional and decide where the user is sent after the reset.
+func (um UserManager) CreatePasswordResetCode(username, clientId, redirectUri string) (PasswordResetCode, error) {
+	path := "/password_resets"
+	query := url.Values{}
+	if clientId != "" {
+		query.Add("client_id", clientId)
+	}
+	if redirectUri != "" {
+		query.Add("redirect_uri", redirectUri)
+	}
+
+	target := um.Config.GetActiveTarget()
+	targetUrl, err := utils.BuildUrl(target.BaseUrl, path)
+	if err != nil {
+		return PasswordResetCode{}, err
+	}
+	targetUrl.RawQuery = query.Encode()
+
+	// The endpoint takes the bare username as the body, not a JSON document.
+	req, err := http.NewRequest("POST", targetUrl.String(), strings.NewReader(username))
+	if err != nil {
+		return PasswordResetCode{}, err
+	}
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/json")
+	req, err = addAuthorization(req, target.GetActiveContext())
+	if err != nil {
+		return PasswordResetCode{}, err
+	}
+	addZoneSwitchHeader(req, &um.Config)
+
+	bytes, err := doAndRead(req, um.HttpClient, um.Config)
+	if err != nil {
+		return PasswordResetCode{}, err
+	}
+
+	resetCode := PasswordResetCode{}
+	if err := json.Unmarshal(bytes, &resetCode); err != nil {
+		return PasswordResetCode{}, parseError(path, bytes)
+	}
+	return resetCode, nil
+}
+
+type PasswordResetResult struct {
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// ResetPassword sets a new password using a code from CreatePasswordResetCode.
+func (um UserManager) ResetPassword(code, password string) (PasswordResetResult, error) {
+	path := "/password_change"
+	body := map[string]string{"code": code, "new_password": password}
+	bytes, err := AuthenticatedRequester{}.PostJson(um.HttpClient, um.Config, path, "", body)
+	if err != nil {
+		return PasswordResetResult{}, err
+	}
+
+	result := PasswordResetResult{}
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		return PasswordResetResult{}, parseError(path, bytes)
+	}
+	return result, nil
+}
+
+// PasswordPolicy is the password policy of the "uaa" identity provider of a
+// zone. The Require fields are the minimum number of characters of each kind.
+type PasswordPolicy struct {
+	MinLength                 int `json:"minLength"`
+	MaxLength                 int `json:"maxLength"`
+	RequireUpperCaseCharacter int `json:"requireUpperCaseCharacter"`
+	RequireLowerCaseCharacter int `json:"requireLowerCaseCharacter"`
+	RequireDigit              int `json:"requireDigit"`
+	RequireSpecialCharacter   int `json:"requireSpecialCharacter"`
+}
+
+// GetPasswordPolicy reads the password policy of the zone, which requires
+// the idps.read scope.
+func GetPasswordPolicy(client *http.Client, config Config) (PasswordPolicy, error) {
+	provider, err := getUaaIdentityProvider(client, config)
+	if err != nil || provider.Config.PasswordPolicy == nil {
+		return PasswordPolicy{}, err
+	}
+	return *provider.Config.PasswordPolicy, nil
+}
+
+const (
+	upperCaseCharacters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	lowerCaseCharacters = "abcdefghijklmnopqrstuvwxyz"
+	digits              = "0123456789"
+	specialCharacters   = "!#$%&()*+,-.:;<=>?@[]^_{|}~"
+)
+
+// GeneratedPasswordLength is the length of generated passwords, unless the
+// policy asks for longer or shorter ones.
+const GeneratedPasswordLength = 24
+
+func randomCharacter(characters string) (byte, error) {
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(len(characters))))
+	if err != nil {
+		return 0, err
+	}
+	return characters[index.Int64()], nil
+}
+
+// Generate returns a random password which satisfies the policy. Where the
+// maximum length leaves room, it contains characters of every kind, so that
+// it also passes policies which have been tightened since they were read.
+func (p PasswordPolicy) Generate() (string, error) {
+	classes := []struct {
+		characters string
+		required   int
+	}{
+		{upperCaseCharacters, p.RequireUpperCaseCharacter},
+		{lowerCaseCharacters, p.RequireLowerCaseCharacter},
+		{digits, p.RequireDigit},
+		{specialCharacters, p.RequireSpecialCharacter},
+	}
+
+	length := GeneratedPasswordLength
+	if p.MinLength > length {
+		length = p.MinLength
+	}
+	if p.MaxLength > 0 && p.MaxLength < length {
+		length = p.MaxLength
+	}
+
+	total := 0
+	for _, class := range classes {
+		total += class.required
+	}
+	if p.MaxLength > 0 && total > p.MaxLength {
+		return "", fmt.Errorf("The password policy requires %v characters of specific kinds but allows at most %v characters.", total, p.MaxLength)
+	}
+	if total > length {
+		length = total
+	}
+
+	password := []byte{}
+	for _, class := range classes {
+		required := class.required
+		if required < 1 && total < length {
+			required = 1
+			total++
+		}
+		for i := 0; i < required; i++ {
+			c, err := randomCharacter(class.characters)
+			if err != nil {
+				return "", err
+			}
+			password = append(password, c)
+		}
+	}
+
+	all := upperCaseCharacters + lowerCaseCharacters + digits + specialCharacters
+	for len(password) < length {
+		c, err := randomCharacter(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
@@ -0,0 +1,251 @@
+package uaa_test
+
+import (
+	"net/http"
+	"strings"
+
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Passwords", func() {
+	var (
+		uaaServer *ghttp.Server
+		config    Config
+	)
+
+	BeforeEach(func() {
+		uaaServer = ghttp.NewServer()
+		config = NewConfigWithServerURL(uaaServer.URL())
+		config.AddContext(NewContextWithToken("access_token"))
+	})
+
+	AfterEach(func() {
+		uaaServer.Close()
+	})
+
+	Describe("UserManager#SetPassword", func() {
+		It("puts the new and old password", func() {
+			uaaServer.RouteToHandler("PUT", "/Users/abcdef/password", ghttp.CombineHandlers(
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+				ghttp.VerifyJSON(`{"password": "n3w", "oldPassword": "0ld"}`),
+				ghttp.RespondWith(http.StatusOK, `{"status": "ok", "message": "password updated"}`),
+			))
+
+			um := UserManager{&http.Client{}, config}
+			err := um.SetPassword("abcdef", "n3w", "0ld")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("leaves out the old password when it is not given", func() {
+			uaaServer.RouteToHandler("PUT", "/Users/abcdef/password", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"password": "n3w"}`),
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			))
+
+			um := UserManager{&http.Client{}, config}
+
+			Expect(um.SetPassword("abcdef", "n3w", "")).To(Succeed())
+		})
+
+		It("returns an error when the UAA rejects the password", func() {
+			uaaServer.RouteToHandler("PUT", "/Users/abcdef/password", ghttp.RespondWith(http.StatusUnprocessableEntity,
+				`{"error": "invalid_password", "error_description": "Password must be at least 8 characters in length."}`))
+
+			um := UserManager{&http.Client{}, config}
+			err := um.SetPassword("abcdef", "n3w", "")
+
+			Expect(err).To(BeAssignableToTypeOf(RequestError{}))
+			Expect(err.(RequestError).Description()).To(Equal("Password must be at least 8 characters in length."))
+		})
+	})
+
+	Describe("UserManager#ExpirePassword", func() {
+		It("requires a password change", func() {
+			uaaServer.RouteToHandler("PATCH", "/Users/abcdef/status", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"passwordChangeRequired": true}`),
+				ghttp.RespondWith(http.StatusOK, `{"passwordChangeRequired": true}`),
+			))
+
+			um := UserManager{&http.Client{}, config}
+
+			Expect(um.ExpirePassword("abcdef")).To(Succeed())
+		})
+	})
+
+	Describe("UserManager#CreatePasswordResetCode", func() {
+		It("posts the bare username", func() {
+			uaaServer.RouteToHandler("POST", "/password_resets", ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/password_resets", ""),
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+				ghttp.VerifyBody([]byte("woodstock")),
+				ghttp.RespondWith(http.StatusCreated, `{"code": "yCQL6a0dJr", "user_id": "abcdef"}`),
+			))
+
+			um := UserManager{&http.Client{}, config}
+			resetCode, err := um.CreatePasswordResetCode("woodstock", "", "")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resetCode).To(Equal(PasswordResetCode{Code: "yCQL6a0dJr", UserId: "abcdef"}))
+		})
+
+		It("sends the client id and redirect URI", func() {
+			uaaServer.RouteToHandler("POST", "/password_resets", ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/password_resets", "client_id=cf&redirect_uri=https%3A%2F%2Fexample.com"),
+				ghttp.RespondWith(http.StatusCreated, `{"code": "yCQL6a0dJr", "user_id": "abcdef"}`),
+			))
+
+			um := UserManager{&http.Client{}, config}
+			_, err := um.CreatePasswordResetCode("woodstock", "cf", "https://example.com")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error when the user cannot be found", func() {
+			uaaServer.RouteToHandler("POST", "/password_resets", ghttp.RespondWith(http.StatusNotFound, ""))
+
+			um := UserManager{&http.Client{}, config}
+			_, err := um.CreatePasswordResetCode("woodstock", "", "")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UserManager#ResetPassword", func() {
+		It("posts the code and the new password", func() {
+			uaaServer.RouteToHandler("POST", "/password_change", ghttp.CombineHandlers(
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+				ghttp.VerifyJSON(`{"code": "yCQL6a0dJr", "new_password": "n3w"}`),
+				ghttp.RespondWith(http.StatusOK, `{"user_id": "abcdef", "username": "woodstock", "email": "woodstock@peanuts.com", "code": "autologin"}`),
+			))
+
+			um := UserManager{&http.Client{}, config}
+			result, err := um.ResetPassword("yCQL6a0dJr", "n3w")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(PasswordResetResult{UserId: "abcdef", Username: "woodstock", Email: "woodstock@peanuts.com"}))
+		})
+
+		It("returns an error when the code has expired", func() {
+			uaaServer.RouteToHandler("POST", "/password_change", ghttp.RespondWith(http.StatusUnprocessableEntity,
+				`{"error": "invalid_code", "error_description": "Sorry, your reset password link is no longer valid."}`))
+
+			um := UserManager{&http.Client{}, config}
+			_, err := um.ResetPassword("yCQL6a0dJr", "n3w")
+
+			Expect(err).To(BeAssignableToTypeOf(RequestError{}))
+		})
+	})
+
+	Describe("GetPasswordPolicy", func() {
+		It("reads the policy of the uaa identity provider", func() {
+			uaaServer.RouteToHandler("GET", "/identity-providers", ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/identity-providers", "rawConfig=true"),
+				ghttp.RespondWith(http.StatusOK, `[
+					{"originKey": "ldap", "config": {}},
+					{"originKey": "uaa", "config": {"passwordPolicy": {"minLength": 30, "maxLength": 40, "requireDigit": 3, "requireSpecialCharacter": 2}}}
+				]`),
+			))
+
+			policy, err := GetPasswordPolicy(&http.Client{}, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(Equal(PasswordPolicy{MinLength: 30, MaxLength: 40, RequireDigit: 3, RequireSpecialCharacter: 2}))
+		})
+
+		It("returns an error when the providers cannot be read", func() {
+			uaaServer.RouteToHandler("GET", "/identity-providers", ghttp.RespondWith(http.StatusForbidden, ""))
+
+			_, err := GetPasswordPolicy(&http.Client{}, config)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("GetLockoutPolicy", func() {
+		It("reads the policy of the uaa identity provider", func() {
+			uaaServer.RouteToHandler("GET", "/identity-providers", ghttp.RespondWith(http.StatusOK, `[
+				{"originKey": "uaa", "config": {"lockoutPolicy": {"lockoutPeriodSeconds": 300, "lockoutAfterFailures": 5, "countFailuresWithin": 3600}}}
+			]`))
+
+			policy, err := GetLockoutPolicy(&http.Client{}, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(Equal(&LockoutPolicy{LockoutPeriodSeconds: 300, LockoutAfterFailures: 5, CountFailuresWithin: 3600}))
+		})
+
+		It("returns nil when the zone has no policy of its own", func() {
+			uaaServer.RouteToHandler("GET", "/identity-providers", ghttp.RespondWith(http.StatusOK, `[{"originKey": "uaa", "config": {}}]`))
+
+			policy, err := GetLockoutPolicy(&http.Client{}, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(BeNil())
+		})
+	})
+
+	Describe("PasswordPolicy#Generate", func() {
+		count := func(password, characters string) int {
+			n := 0
+			for _, c := range password {
+				if strings.ContainsRune(characters, c) {
+					n++
+				}
+			}
+			return n
+		}
+
+		It("generates passwords of the default length with every kind of character", func() {
+			password, err := PasswordPolicy{}.Generate()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(password).To(HaveLen(GeneratedPasswordLength))
+			Expect(count(password, "ABCDEFGHIJKLMNOPQRSTUVWXYZ")).To(BeNumerically(">=", 1))
+			Expect(count(password, "abcdefghijklmnopqrstuvwxyz")).To(BeNumerically(">=", 1))
+			Expect(count(password, "0123456789")).To(BeNumerically(">=", 1))
+		})
+
+		It("satisfies the lengths and character counts of the policy", func() {
+			policy := PasswordPolicy{MinLength: 32, MaxLength: 40, RequireUpperCaseCharacter: 5, RequireDigit: 6}
+			for i := 0; i < 20; i++ {
+				password, err := policy.Generate()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(password).To(HaveLen(32))
+				Expect(count(password, "ABCDEFGHIJKLMNOPQRSTUVWXYZ")).To(BeNumerically(">=", 5))
+				Expect(count(password, "0123456789")).To(BeNumerically(">=", 6))
+			}
+		})
+
+		It("respects a maximum length below the default", func() {
+			password, _ := PasswordPolicy{MaxLength: 12}.Generate()
+
+			Expect(password).To(HaveLen(12))
+		})
+
+		It("stays within a maximum length too short for every kind of character", func() {
+			password, err := PasswordPolicy{MaxLength: 3, RequireDigit: 2}.Generate()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(password).To(HaveLen(3))
+			Expect(count(password, "0123456789")).To(BeNumerically(">=", 2))
+		})
+
+		It("refuses policies which require more characters than they allow", func() {
+			_, err := PasswordPolicy{MaxLength: 4, RequireDigit: 3, RequireUpperCaseCharacter: 2}.Generate()
+
+			Expect(err).To(MatchError("The password policy requires 5 characters of specific kinds but allows at most 4 characters."))
+		})
+
+		It("generates a different password each time", func() {
+			first, _ := PasswordPolicy{}.Generate()
+			second, _ := PasswordPolicy{}.Generate()
+
+			Expect(first).NotTo(Equal(second))
+		})
+	})
+})
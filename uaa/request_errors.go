@@ -1,9 +1,34 @@
 package uaa
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+)
+
+// RequestError is returned when the UAA could not be reached or responded
+// with a non-2xx status. StatusCode is 0 when no response was received.
+type RequestError struct {
+	Url           string
+	StatusCode    int
+	ErrorResponse []byte
+}
+
+func (re RequestError) Error() string {
+	return "An unknown error occurred while calling " + re.Url
+}
+
+// Description returns the error_description sent by the UAA with the error
+// response, or "" if the response had none.
+func (re RequestError) Description() string {
+	response := oauthErrorResponse{}
+	if json.Unmarshal(re.ErrorResponse, &response) != nil {
+		return ""
+	}
+	return response.Description
+}
 
 func requestError(url string) error {
-	return errors.New("An unknown error occurred while calling " + url)
+	return RequestError{Url: url}
 }
 
 func parseError(url string, body []byte) error {
@@ -0,0 +1,98 @@
+package uaa
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// StringList decodes claims which may be sent either as a single
+// space-delimited string or as a JSON array, such as scope and aud.
+type StringList []string
+
+func (sl *StringList) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*sl = list
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*sl = strings.Fields(value)
+	return nil
+}
+
+type TokenIntrospection struct {
+	Active    bool       `json:"active"`
+	Scope     StringList `json:"scope,omitempty"`
+	Audience  StringList `json:"aud,omitempty"`
+	ClientId  string     `json:"client_id,omitempty"`
+	Username  string     `json:"user_name,omitempty"`
+	UserId    string     `json:"user_id,omitempty"`
+	Origin    string     `json:"origin,omitempty"`
+	GrantType string     `json:"grant_type,omitempty"`
+	Subject   string     `json:"sub,omitempty"`
+	Issuer    string     `json:"iss,omitempty"`
+	ZoneId    string     `json:"zid,omitempty"`
+	JTI       string     `json:"jti,omitempty"`
+	IssuedAt  int64      `json:"iat,omitempty"`
+	ExpiresAt int64      `json:"exp,omitempty"`
+}
+
+// IntrospectionClient asks the UAA whether a token is valid. The client must
+// have the uaa.resource authority. Clients authenticate with HTTP Basic
+// unless ClientAuth is set.
+type IntrospectionClient struct {
+	ClientId     string
+	ClientSecret string
+	ClientAuth   ClientAuthentication
+}
+
+func (ic IntrospectionClient) clientAuth() ClientAuthentication {
+	if ic.ClientAuth != nil {
+		return ic.ClientAuth
+	}
+	return ClientSecretBasic{ClientId: ic.ClientId, ClientSecret: ic.ClientSecret}
+}
+
+// Introspect calls the RFC 7662 /introspect endpoint, falling back to the
+// older /check_token endpoint on UAAs which do not provide it.
+func (ic IntrospectionClient) Introspect(httpClient *http.Client, config Config, token string) (TokenIntrospection, error) {
+	body, err := postFormWithClientAuth(ic.clientAuth(), httpClient, config, "/introspect", map[string]string{"token": token})
+	if requestErr, ok := err.(RequestError); ok && requestErr.StatusCode == http.StatusNotFound {
+		return ic.checkToken(httpClient, config, token)
+	}
+	if err != nil {
+		return TokenIntrospection{}, err
+	}
+
+	introspection := TokenIntrospection{}
+	err = json.Unmarshal(body, &introspection)
+	if err != nil {
+		return TokenIntrospection{}, parseError("/introspect", body)
+	}
+	return introspection, nil
+}
+
+func (ic IntrospectionClient) checkToken(httpClient *http.Client, config Config, token string) (TokenIntrospection, error) {
+	body, err := postFormWithClientAuth(ic.clientAuth(), httpClient, config, "/check_token", map[string]string{"token": token})
+	if err != nil {
+		// Unlike /introspect, /check_token reports inactive tokens as errors.
+		if requestErr, ok := err.(RequestError); ok && requestErr.StatusCode == http.StatusBadRequest &&
+			strings.Contains(string(requestErr.ErrorResponse), "invalid_token") {
+			return TokenIntrospection{Active: false}, nil
+		}
+		return TokenIntrospection{}, err
+	}
+
+	introspection := TokenIntrospection{}
+	err = json.Unmarshal(body, &introspection)
+	if err != nil {
+		return TokenIntrospection{}, parseError("/check_token", body)
+	}
+	introspection.Active = true
+	return introspection, nil
+}
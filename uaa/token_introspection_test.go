@@ -0,0 +1,126 @@
+package uaa_test
+
+import (
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"net/http"
+)
+
+var _ = Describe("TokenIntrospection", func() {
+	var (
+		server *ghttp.Server
+		client *http.Client
+		config Config
+		ic     IntrospectionClient
+	)
+
+	const introspectResponse = `{
+	  "active" : true,
+	  "scope" : "openid scim.read",
+	  "aud" : [ "scim", "openid" ],
+	  "client_id" : "cf",
+	  "user_name" : "woodstock",
+	  "user_id" : "abcdef",
+	  "exp" : 1500000000,
+	  "iat" : 1499956800,
+	  "jti" : "bc4885d950854fed9a938e96b13ca519"
+	}`
+
+	const checkTokenResponse = `{
+	  "scope" : [ "openid", "scim.read" ],
+	  "aud" : [ "scim", "openid" ],
+	  "client_id" : "cf",
+	  "user_name" : "woodstock",
+	  "exp" : 1500000000
+	}`
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = &http.Client{}
+		config = NewConfigWithServerURL(server.URL())
+		ic = IntrospectionClient{ClientId: "app", ClientSecret: "appsecret"}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("posts the token to /introspect with HTTP Basic authentication", func() {
+		server.RouteToHandler("POST", "/introspect", ghttp.CombineHandlers(
+			ghttp.RespondWith(http.StatusOK, introspectResponse),
+			ghttp.VerifyBasicAuth("app", "appsecret"),
+			ghttp.VerifyFormKV("token", "some-token"),
+		))
+
+		introspection, err := ic.Introspect(client, config, "some-token")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(introspection.Active).To(BeTrue())
+		Expect(introspection.Scope).To(Equal(StringList{"openid", "scim.read"}))
+		Expect(introspection.Audience).To(Equal(StringList{"scim", "openid"}))
+		Expect(introspection.ClientId).To(Equal("cf"))
+		Expect(introspection.Username).To(Equal("woodstock"))
+		Expect(introspection.ExpiresAt).To(Equal(int64(1500000000)))
+	})
+
+	It("reports inactive tokens", func() {
+		server.RouteToHandler("POST", "/introspect", ghttp.RespondWith(http.StatusOK, `{"active":false}`))
+
+		introspection, err := ic.Introspect(client, config, "some-token")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(introspection.Active).To(BeFalse())
+	})
+
+	It("returns an error when the client is not authorized", func() {
+		server.RouteToHandler("POST", "/introspect", ghttp.RespondWith(http.StatusUnauthorized, `{"error":"unauthorized"}`))
+
+		_, err := ic.Introspect(client, config, "some-token")
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.(RequestError).StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	Describe("when /introspect is not available", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("POST", "/introspect", ghttp.RespondWith(http.StatusNotFound, ""))
+		})
+
+		It("falls back to /check_token", func() {
+			server.RouteToHandler("POST", "/check_token", ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusOK, checkTokenResponse),
+				ghttp.VerifyBasicAuth("app", "appsecret"),
+				ghttp.VerifyFormKV("token", "some-token"),
+			))
+
+			introspection, err := ic.Introspect(client, config, "some-token")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+			Expect(introspection.Active).To(BeTrue())
+			Expect(introspection.Scope).To(Equal(StringList{"openid", "scim.read"}))
+			Expect(introspection.Username).To(Equal("woodstock"))
+		})
+
+		It("reports invalid tokens as inactive", func() {
+			server.RouteToHandler("POST", "/check_token", ghttp.RespondWith(http.StatusBadRequest, `{"error":"invalid_token","error_description":"Token has expired"}`))
+
+			introspection, err := ic.Introspect(client, config, "some-token")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(introspection.Active).To(BeFalse())
+		})
+
+		It("returns other errors", func() {
+			server.RouteToHandler("POST", "/check_token", ghttp.RespondWith(http.StatusUnauthorized, `{"error":"unauthorized"}`))
+
+			_, err := ic.Introspect(client, config, "some-token")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
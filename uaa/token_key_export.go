@@ -0,0 +1,85 @@
+package uaa
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// PublicJWK is a key in the standard JWKS format of RFC 7517, without the
+// UAA specific value field.
+type PublicJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type JWKS struct {
+	Keys []PublicJWK `json:"keys"`
+}
+
+func (k JWK) IsSymmetric() bool {
+	return k.Kty == "MAC" || k.Kty == "oct"
+}
+
+// PublicKeyDER returns the PKIX, ASN.1 DER encoding of an RSA public key.
+func (k JWK) PublicKeyDER() ([]byte, error) {
+	if k.IsSymmetric() {
+		return nil, fmt.Errorf(`Key "%v" is a symmetric key and has no public key.`, k.Kid)
+	}
+	publicKey, err := k.RSAPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return x509.MarshalPKIXPublicKey(publicKey)
+}
+
+func (k JWK) PublicKeyPEM() ([]byte, error) {
+	der, err := k.PublicKeyDER()
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// Fingerprint is the SHA-256 digest of the DER encoded public key, in the
+// colon separated hex form printed by openssl.
+func (k JWK) Fingerprint() (string, error) {
+	der, err := k.PublicKeyDER()
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(der)
+	hex := make([]string, len(digest))
+	for i, b := range digest {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return "SHA256:" + strings.Join(hex, ":"), nil
+}
+
+// Public converts the key into the standard JWK format. The modulus and
+// exponent are derived from the PEM value when the UAA did not send them.
+func (k JWK) Public() (PublicJWK, error) {
+	if k.IsSymmetric() {
+		return PublicJWK{}, fmt.Errorf(`Key "%v" is a symmetric key and has no public key.`, k.Kid)
+	}
+	publicKey, err := k.RSAPublicKey()
+	if err != nil {
+		return PublicJWK{}, err
+	}
+	return PublicJWK{
+		Kty: "RSA",
+		Kid: k.Kid,
+		Use: k.Use,
+		Alg: k.Alg,
+		N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+	}, nil
+}
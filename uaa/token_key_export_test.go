@@ -0,0 +1,50 @@
+package uaa_test
+
+import (
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exporting token keys", func() {
+	const publicKeyPem = "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA0m59l2u9iDnMbrXHfqkO\nrn2dVQ3vfBJqcDuFUK03d+1PZGbVlNCqnkpIJ8syFppW8ljnWweP7+LiWpRoz0I7\nfYb3d8TjhV86Y997Fl4DBrxgM6KTJOuE/uxnoDhZQ14LgOU2ckXjOzOdTsnGMKQB\nLCl0vpcXBtFLMaSbpv1ozi8h7DJyVZ6EnFQZUWGdgTMhDrmqevfx95U/16c5WBDO\nkqwIn7Glry9n9Suxygbf8g5AzpWcusZgDLIIZ7JTUldBb8qU2a0Dl4mvLZOn4wPo\njfj9Cw2QICsc5+Pwf21fP+hzf+1WSRHbnYv8uanRO0gZ8ekGaghM/2H6gqJbo2nI\nJwIDAQAB\n-----END PUBLIC KEY-----\n"
+	const modulus = "ANJufZdrvYg5zG61x36pDq59nVUN73wSanA7hVCtN3ftT2Rm1ZTQqp5KSCfLMhaaVvJY51sHj-_i4lqUaM9CO32G93fE44VfOmPfexZeAwa8YDOikyTrhP7sZ6A4WUNeC4DlNnJF4zsznU7JxjCkASwpdL6XFwbRSzGkm6b9aM4vIewyclWehJxUGVFhnYEzIQ65qnr38feVP9enOVgQzpKsCJ-xpa8vZ_UrscoG3_IOQM6VnLrGYAyyCGeyU1JXQW_KlNmtA5eJry2Tp-MD6I34_QsNkCArHOfj8H9tXz_oc3_tVkkR252L_Lmp0TtIGfHpBmoITP9h-oKiW6NpyCc"
+
+	It("converts the modulus and exponent into a PEM public key", func() {
+		key := JWK{Kty: "RSA", Kid: "testKey", N: modulus, E: "AQAB"}
+
+		pemBytes, err := key.PublicKeyPEM()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(pemBytes)).To(Equal(publicKeyPem))
+	})
+
+	It("uses the PEM value when the modulus and exponent are missing", func() {
+		key := JWK{Kty: "RSA", Kid: "testKey", Value: publicKeyPem}
+
+		public, err := key.Public()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(public.Kid).To(Equal("testKey"))
+		Expect(public.E).To(Equal("AQAB"))
+		pemBytes, _ := JWK{Kty: "RSA", N: public.N, E: public.E}.PublicKeyPEM()
+		Expect(string(pemBytes)).To(Equal(publicKeyPem))
+	})
+
+	It("computes the same fingerprint from either representation", func() {
+		fromModulus, err := JWK{Kty: "RSA", N: modulus, E: "AQAB"}.Fingerprint()
+		Expect(err).NotTo(HaveOccurred())
+		fromValue, err := JWK{Kty: "RSA", Value: publicKeyPem}.Fingerprint()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fromModulus).To(Equal(fromValue))
+		Expect(fromModulus).To(MatchRegexp(`^SHA256:([0-9A-F]{2}:){31}[0-9A-F]{2}$`))
+	})
+
+	It("refuses to export symmetric keys", func() {
+		_, err := JWK{Kty: "MAC", Kid: "legacy", Value: "tokenkey"}.PublicKeyDER()
+
+		Expect(err).To(MatchError(`Key "legacy" is a symmetric key and has no public key.`))
+	})
+})
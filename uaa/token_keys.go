@@ -10,7 +10,8 @@ type Keys struct {
 }
 
 func TokenKeys(client *http.Client, config Config) ([]JWK, error) {
-	body, err := UnauthenticatedRequester{}.Get(client, config, "/token_keys", "")
+	path := config.GetActiveTarget().JwksUri()
+	body, err := UnauthenticatedRequester{}.Get(client, config, path, "")
 	if err != nil {
 		key, err := TokenKey(client, config)
 		return []JWK{key}, err
@@ -19,7 +20,7 @@ func TokenKeys(client *http.Client, config Config) ([]JWK, error) {
 	keys := Keys{}
 	err = json.Unmarshal(body, &keys)
 	if err != nil {
-		return []JWK{}, parseError("/token_keys", body)
+		return []JWK{}, parseError(path, body)
 	}
 
 	return keys.Keys, nil
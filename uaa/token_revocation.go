@@ -0,0 +1,54 @@
+package uaa
+
+import (
+	"net/http"
+	"net/url"
+)
+
+type TokenRevoker struct {
+	HttpClient *http.Client
+	Config     Config
+}
+
+// RevokeToken revokes a single token by its ID. For JWTs the ID is the jti
+// claim; for opaque tokens it is the token value itself.
+func (tr TokenRevoker) RevokeToken(tokenId string) error {
+	_, err := AuthenticatedRequester{}.Delete(tr.HttpClient, tr.Config, "/oauth/token/revoke/"+url.PathEscape(tokenId), "")
+	return err
+}
+
+func (tr TokenRevoker) RevokeUserTokens(userId string) error {
+	_, err := AuthenticatedRequester{}.Get(tr.HttpClient, tr.Config, "/oauth/token/revoke/user/"+url.PathEscape(userId), "")
+	return err
+}
+
+func (tr TokenRevoker) RevokeClientTokens(clientId string) error {
+	_, err := AuthenticatedRequester{}.Get(tr.HttpClient, tr.Config, "/oauth/token/revoke/client/"+url.PathEscape(clientId), "")
+	return err
+}
+
+func (tr TokenRevoker) RevokeUserClientTokens(userId, clientId string) error {
+	path := "/oauth/token/revoke/user/" + url.PathEscape(userId) + "/client/" + url.PathEscape(clientId)
+	_, err := AuthenticatedRequester{}.Get(tr.HttpClient, tr.Config, path, "")
+	return err
+}
+
+// RevocationClient revokes tokens with the RFC 7009 revocation endpoint,
+// authenticating as the client the token was issued to.
+type RevocationClient struct {
+	ClientId     string
+	ClientSecret string
+	ClientAuth   ClientAuthentication
+}
+
+func (rc RevocationClient) Revoke(httpClient *http.Client, config Config, token, tokenTypeHint string) error {
+	body := map[string]string{
+		"token": token,
+	}
+	if tokenTypeHint != "" {
+		body["token_type_hint"] = tokenTypeHint
+	}
+
+	_, err := postFormWithClientAuth(clientAuthOrDefault(rc.ClientAuth, rc.ClientId, rc.ClientSecret), httpClient, config, "/oauth/revoke", body)
+	return err
+}
@@ -0,0 +1,137 @@
+package uaa_test
+
+import (
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"net/http"
+)
+
+var _ = Describe("TokenRevocation", func() {
+	var (
+		server *ghttp.Server
+		client *http.Client
+		config Config
+		tr     TokenRevoker
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = &http.Client{}
+		config = NewConfigWithServerURL(server.URL())
+		config.AddContext(NewContextWithToken("access_token"))
+		tr = TokenRevoker{HttpClient: client, Config: config}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("TokenRevoker#RevokeToken", func() {
+		It("deletes the token by id", func() {
+			server.RouteToHandler("DELETE", "/oauth/token/revoke/abc123", ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusOK, ""),
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+			))
+
+			err := tr.RevokeToken("abc123")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns an error when the request fails", func() {
+			server.RouteToHandler("DELETE", "/oauth/token/revoke/abc123",
+				ghttp.RespondWith(http.StatusUnauthorized, `{"error":"unauthorized"}`),
+			)
+
+			err := tr.RevokeToken("abc123")
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("does not send a revoked access token", func() {
+			ctx := config.GetActiveContext()
+			ctx.Revoked = true
+			config.AddContext(ctx)
+
+			err := TokenRevoker{HttpClient: client, Config: config}.RevokeToken("abc123")
+
+			Expect(err).To(MatchError(ContainSubstring("The access token in the active context has been revoked.")))
+			Expect(server.ReceivedRequests()).To(HaveLen(0))
+		})
+	})
+
+	Describe("TokenRevoker#RevokeUserTokens", func() {
+		It("calls the user revocation endpoint", func() {
+			server.RouteToHandler("GET", "/oauth/token/revoke/user/user-id", ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusOK, ""),
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+			))
+
+			Expect(tr.RevokeUserTokens("user-id")).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("TokenRevoker#RevokeClientTokens", func() {
+		It("calls the client revocation endpoint", func() {
+			server.RouteToHandler("GET", "/oauth/token/revoke/client/shinyclient", ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusOK, ""),
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+			))
+
+			Expect(tr.RevokeClientTokens("shinyclient")).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("TokenRevoker#RevokeUserClientTokens", func() {
+		It("calls the user and client revocation endpoint", func() {
+			server.RouteToHandler("GET", "/oauth/token/revoke/user/user-id/client/shinyclient", ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusOK, ""),
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+			))
+
+			Expect(tr.RevokeUserClientTokens("user-id", "shinyclient")).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("RevocationClient#Revoke", func() {
+		It("posts the token to /oauth/revoke with client credentials", func() {
+			server.RouteToHandler("POST", "/oauth/revoke", ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusOK, ""),
+				ghttp.VerifyHeaderKV("Content-Type", "application/x-www-form-urlencoded"),
+				ghttp.VerifyFormKV("token", "some-refresh-token"),
+				ghttp.VerifyFormKV("token_type_hint", "refresh_token"),
+				ghttp.VerifyFormKV("client_id", "shinyclient"),
+				ghttp.VerifyFormKV("client_secret", "shinysecret"),
+			))
+
+			rc := RevocationClient{ClientId: "shinyclient", ClientSecret: "shinysecret"}
+			err := rc.Revoke(client, config, "some-refresh-token", "refresh_token")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("omits the token type hint when none is given", func() {
+			server.RouteToHandler("POST", "/oauth/revoke", ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusOK, ""),
+				ghttp.VerifyBasicAuth("shinyclient", "shinysecret"),
+				func(w http.ResponseWriter, req *http.Request) {
+					req.ParseForm()
+					Expect(req.Form).NotTo(HaveKey("token_type_hint"))
+				},
+			))
+
+			rc := RevocationClient{ClientAuth: ClientSecretBasic{ClientId: "shinyclient", ClientSecret: "shinysecret"}}
+			err := rc.Revoke(client, config, "some-access-token", "")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,189 @@
+package uaa
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	// Register the hash functions used by the supported signing algorithms.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+var signingHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"HS256": crypto.SHA256,
+	"HS384": crypto.SHA384,
+	"HS512": crypto.SHA512,
+}
+
+// TokenVerifier checks the signature and standard claims of a JWT without
+// contacting the UAA. Audience is only checked when set.
+type TokenVerifier struct {
+	Keys     []JWK
+	Issuer   string
+	Audience string
+	Now      time.Time
+}
+
+// HasKey reports whether keys contain a key with the given kid. Tokens
+// without a kid can be checked against any key.
+func HasKey(keys []JWK, kid string) bool {
+	if kid == "" {
+		return len(keys) > 0
+	}
+	for _, key := range keys {
+		if key.Kid == kid {
+			return true
+		}
+	}
+	return false
+}
+
+func (tv TokenVerifier) Verify(token string) (Jwt, error) {
+	jwt, err := ParseJwt(token)
+	if err != nil {
+		return Jwt{}, err
+	}
+
+	if err := tv.verifySignature(jwt); err != nil {
+		return jwt, err
+	}
+	if err := tv.verifyClaims(jwt); err != nil {
+		return jwt, err
+	}
+	return jwt, nil
+}
+
+func (tv TokenVerifier) verifySignature(jwt Jwt) error {
+	alg, _ := jwt.Header["alg"].(string)
+	if _, ok := signingHashes[alg]; !ok {
+		return fmt.Errorf(`The token is signed with the unsupported algorithm "%v".`, alg)
+	}
+
+	kid, _ := jwt.Header["kid"].(string)
+	candidates := []JWK{}
+	for _, key := range tv.Keys {
+		if kid == "" || key.Kid == kid {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf(`No token key with kid "%v" was found.`, kid)
+	}
+
+	var lastErr error
+	for _, key := range candidates {
+		lastErr = verifyWithKey(jwt, alg, key)
+		if lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+func verifyWithKey(jwt Jwt, alg string, key JWK) error {
+	hash := signingHashes[alg]
+	hasher := hash.New()
+	hasher.Write([]byte(jwt.SigningInput))
+	digest := hasher.Sum(nil)
+
+	switch {
+	case strings.HasPrefix(alg, "RS"):
+		if key.Kty != "RSA" {
+			return fmt.Errorf(`The token is signed with %v but key "%v" is not an RSA key.`, alg, key.Kid)
+		}
+		publicKey, err := key.RSAPublicKey()
+		if err != nil {
+			return err
+		}
+		if rsa.VerifyPKCS1v15(publicKey, hash, digest, jwt.Signature) != nil {
+			return errors.New("The token signature is invalid.")
+		}
+	case strings.HasPrefix(alg, "HS"):
+		if !key.IsSymmetric() {
+			return fmt.Errorf(`The token is signed with %v but key "%v" is not a symmetric key.`, alg, key.Kid)
+		}
+		if key.Value == "" {
+			return fmt.Errorf(`The symmetric key "%v" is not visible to this client.`, key.Kid)
+		}
+		mac := hmac.New(hash.New, []byte(key.Value))
+		mac.Write([]byte(jwt.SigningInput))
+		if !hmac.Equal(mac.Sum(nil), jwt.Signature) {
+			return errors.New("The token signature is invalid.")
+		}
+	}
+	return nil
+}
+
+func (tv TokenVerifier) verifyClaims(jwt Jwt) error {
+	now := tv.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	exp, ok := jwt.TimeClaim("exp")
+	if !ok {
+		return errors.New("The token has no exp claim.")
+	}
+	if !now.Before(exp) {
+		return fmt.Errorf("The token expired at %v.", exp.Format(time.RFC3339))
+	}
+
+	if tv.Issuer != "" && jwt.StringClaim("iss") != tv.Issuer {
+		return fmt.Errorf(`The token was issued by "%v", not "%v".`, jwt.StringClaim("iss"), tv.Issuer)
+	}
+
+	if tv.Audience != "" {
+		for _, aud := range jwt.Audience() {
+			if aud == tv.Audience {
+				return nil
+			}
+		}
+		return fmt.Errorf(`The token audience [%v] does not include "%v".`, strings.Join(jwt.Audience(), ", "), tv.Audience)
+	}
+	return nil
+}
+
+// RSAPublicKey builds the public key from the modulus and exponent of the
+// JWK, or from its PEM encoded value when those are absent.
+func (k JWK) RSAPublicKey() (*rsa.PublicKey, error) {
+	if k.N != "" && k.E != "" {
+		n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.N, "="))
+		if err != nil {
+			return nil, fmt.Errorf(`The modulus of key "%v" could not be decoded.`, k.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.E, "="))
+		if err != nil {
+			return nil, fmt.Errorf(`The exponent of key "%v" could not be decoded.`, k.Kid)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	}
+
+	block, _ := pem.Decode([]byte(k.Value))
+	if block == nil {
+		return nil, fmt.Errorf(`Key "%v" has no usable public key.`, k.Kid)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf(`The public key of key "%v" could not be parsed.`, k.Kid)
+	}
+	rsaKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf(`Key "%v" is not an RSA key.`, k.Kid)
+	}
+	return rsaKey, nil
+}
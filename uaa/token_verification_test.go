@@ -0,0 +1,154 @@
+package uaa_test
+
+import (
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math/big"
+	"strings"
+	"time"
+)
+
+func splitToken(token string) (string, string, string) {
+	segments := strings.Split(token, ".")
+	return segments[0], segments[1], segments[2]
+}
+
+var _ = Describe("TokenVerifier", func() {
+	encode := func(segment []byte) string {
+		return base64.RawURLEncoding.EncodeToString(segment)
+	}
+
+	var (
+		privateKey *rsa.PrivateKey
+		rsaKey     JWK
+		verifier   TokenVerifier
+	)
+
+	signRS256 := func(header, claims string) string {
+		input := encode([]byte(header)) + "." + encode([]byte(claims))
+		digest := sha256.Sum256([]byte(input))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+		Expect(err).NotTo(HaveOccurred())
+		return input + "." + encode(signature)
+	}
+
+	BeforeEach(func() {
+		var err error
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		rsaKey = JWK{
+			Kty: "RSA",
+			Kid: "key-1",
+			Alg: "RS256",
+			N:   encode(privateKey.N.Bytes()),
+			E:   encode(big.NewInt(int64(privateKey.E)).Bytes()),
+		}
+		verifier = TokenVerifier{
+			Keys:   []JWK{rsaKey},
+			Issuer: "https://uaa.example.com/oauth/token",
+			Now:    time.Unix(1499956800, 0),
+		}
+	})
+
+	It("accepts a token signed with the matching RSA key", func() {
+		token := signRS256(`{"alg":"RS256","kid":"key-1"}`, `{"iss":"https://uaa.example.com/oauth/token","exp":1500000000}`)
+
+		jwt, err := verifier.Verify(token)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jwt.StringClaim("iss")).To(Equal("https://uaa.example.com/oauth/token"))
+	})
+
+	It("reads RSA keys given only as a PEM value", func() {
+		der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		verifier.Keys = []JWK{{Kty: "RSA", Kid: "key-1", Value: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))}}
+		token := signRS256(`{"alg":"RS256","kid":"key-1"}`, `{"iss":"https://uaa.example.com/oauth/token","exp":1500000000}`)
+
+		_, err = verifier.Verify(token)
+
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("accepts a token signed with a visible symmetric key", func() {
+		verifier.Keys = []JWK{{Kty: "MAC", Kid: "legacy", Value: "tokenkey"}}
+		input := encode([]byte(`{"alg":"HS256","kid":"legacy"}`)) + "." + encode([]byte(`{"iss":"https://uaa.example.com/oauth/token","exp":1500000000}`))
+		mac := hmac.New(sha256.New, []byte("tokenkey"))
+		mac.Write([]byte(input))
+
+		_, err := verifier.Verify(input + "." + encode(mac.Sum(nil)))
+
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects a tampered token", func() {
+		token := signRS256(`{"alg":"RS256","kid":"key-1"}`, `{"iss":"https://uaa.example.com/oauth/token","exp":1500000000}`)
+		header, _, signature := splitToken(token)
+		claims := encode([]byte(`{"iss":"https://uaa.example.com/oauth/token","exp":1600000000}`))
+
+		_, err := verifier.Verify(header + "." + claims + "." + signature)
+
+		Expect(err).To(MatchError("The token signature is invalid."))
+	})
+
+	It("rejects tokens signed with an unknown key", func() {
+		token := signRS256(`{"alg":"RS256","kid":"key-2"}`, `{"exp":1500000000}`)
+
+		_, err := verifier.Verify(token)
+
+		Expect(err).To(MatchError(`No token key with kid "key-2" was found.`))
+	})
+
+	It("rejects unsigned tokens", func() {
+		token := encode([]byte(`{"alg":"none"}`)) + "." + encode([]byte(`{"exp":1500000000}`)) + "."
+
+		_, err := verifier.Verify(token)
+
+		Expect(err).To(MatchError(`The token is signed with the unsupported algorithm "none".`))
+	})
+
+	It("rejects expired tokens", func() {
+		verifier.Now = time.Unix(1500000000, 0)
+		token := signRS256(`{"alg":"RS256","kid":"key-1"}`, `{"iss":"https://uaa.example.com/oauth/token","exp":1500000000}`)
+
+		_, err := verifier.Verify(token)
+
+		Expect(err).To(MatchError("The token expired at " + time.Unix(1500000000, 0).Format(time.RFC3339) + "."))
+	})
+
+	It("rejects tokens from another issuer", func() {
+		token := signRS256(`{"alg":"RS256","kid":"key-1"}`, `{"iss":"https://other.example.com/oauth/token","exp":1500000000}`)
+
+		_, err := verifier.Verify(token)
+
+		Expect(err).To(MatchError(`The token was issued by "https://other.example.com/oauth/token", not "https://uaa.example.com/oauth/token".`))
+	})
+
+	It("checks the audience when one is expected", func() {
+		verifier.Audience = "cloud_controller"
+		token := signRS256(`{"alg":"RS256","kid":"key-1"}`, `{"iss":"https://uaa.example.com/oauth/token","exp":1500000000,"aud":["scim","openid"]}`)
+
+		_, err := verifier.Verify(token)
+
+		Expect(err).To(MatchError(`The token audience [scim, openid] does not include "cloud_controller".`))
+	})
+
+	Describe("HasKey", func() {
+		It("matches keys by kid", func() {
+			Expect(HasKey([]JWK{rsaKey}, "key-1")).To(BeTrue())
+			Expect(HasKey([]JWK{rsaKey}, "key-2")).To(BeFalse())
+			Expect(HasKey([]JWK{rsaKey}, "")).To(BeTrue())
+			Expect(HasKey(nil, "")).To(BeFalse())
+		})
+	})
+})
@@ -16,6 +16,8 @@ type Target struct {
 	SkipSSLValidation bool
 	Contexts          map[string]UaaContext
 	ActiveContextName string
+	TokenKeys         []JWK                `json:",omitempty"`
+	Discovery         *OpenIdConfiguration `json:",omitempty"`
 }
 
 type UaaContext struct {
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
 	"strconv"
 
 	"code.cloudfoundry.org/uaa-cli/utils"
@@ -146,25 +145,7 @@ const (
 func (um UserManager) List(filter, sortBy, attributes string, sortOrder ScimSortOrder, startIdx, count int) (PaginatedUserList, error) {
 	endpoint := "/Users"
 
-	query := url.Values{}
-	if filter != "" {
-		query.Add("filter", filter)
-	}
-	if attributes != "" {
-		query.Add("attributes", attributes)
-	}
-	if sortBy != "" {
-		query.Add("sortBy", sortBy)
-	}
-	if count != 0 {
-		query.Add("count", strconv.Itoa(count))
-	}
-	if startIdx != 0 {
-		query.Add("startIndex", strconv.Itoa(startIdx))
-	}
-	if sortOrder != "" {
-		query.Add("sortOrder", string(sortOrder))
-	}
+	query := scimQuery(filter, sortBy, attributes, sortOrder, startIdx, count)
 
 	bytes, err := AuthenticatedRequester{}.Get(um.HttpClient, um.Config, endpoint, query.Encode())
 	if err != nil {
@@ -212,6 +193,49 @@ func (um UserManager) Update(toUpdate ScimUser) (ScimUser, error) {
 	return updated, err
 }
 
+func (um UserManager) Patch(userID string, userMetaVersion int, changes interface{}) (ScimUser, error) {
+	url := "/Users/" + userID
+	extraHeaders := map[string]string{"If-Match": strconv.Itoa(userMetaVersion)}
+	bytes, err := AuthenticatedRequester{}.PatchJson(um.HttpClient, um.Config, url, "", changes, extraHeaders)
+	if err != nil {
+		return ScimUser{}, err
+	}
+
+	patched := ScimUser{}
+	err = json.Unmarshal(bytes, &patched)
+	if err != nil {
+		return ScimUser{}, parseError(url, bytes)
+	}
+
+	return patched, err
+}
+
+// PatchAttempts is how often PatchCurrent applies a patch before giving up on
+// a user which keeps changing underneath it.
+const PatchAttempts = 3
+
+// PatchCurrent patches the user at the version it was read with. When the UAA
+// answers 412 Precondition Failed because the user changed in the meantime,
+// the user is read again and the patch applied to its current version.
+func (um UserManager) PatchCurrent(user ScimUser, changes interface{}) (ScimUser, error) {
+	for attempt := 1; ; attempt++ {
+		if user.Meta == nil {
+			return ScimUser{}, errors.New("The user did not have expected metadata version.")
+		}
+
+		patched, err := um.Patch(user.ID, user.Meta.Version, changes)
+		requestErr, isRequestErr := err.(RequestError)
+		if !isRequestErr || requestErr.StatusCode != http.StatusPreconditionFailed || attempt == PatchAttempts {
+			return patched, err
+		}
+
+		user, err = um.Get(user.ID)
+		if err != nil {
+			return ScimUser{}, err
+		}
+	}
+}
+
 func (um UserManager) Delete(userId string) (ScimUser, error) {
 	url := "/Users/" + userId
 	bytes, err := AuthenticatedRequester{}.Delete(um.HttpClient, um.Config, url, "")
@@ -246,3 +270,22 @@ func (um UserManager) setActive(active bool, userID string, userMetaVersion int)
 
 	return err
 }
+
+// UserStatus changes the lockout and password state of a user. The UAA only
+// accepts Locked set to false and PasswordChangeRequired set to true.
+type UserStatus struct {
+	Locked                 *bool `json:"locked,omitempty"`
+	PasswordChangeRequired *bool `json:"passwordChangeRequired,omitempty"`
+}
+
+func (um UserManager) SetStatus(userID string, status UserStatus) error {
+	url := "/Users/" + userID + "/status"
+	_, err := AuthenticatedRequester{}.PatchJson(um.HttpClient, um.Config, url, "", status, nil)
+	return err
+}
+
+// Unlock lets a user who was locked after too many failed logins log in again.
+func (um UserManager) Unlock(userID string) error {
+	locked := false
+	return um.SetStatus(userID, UserStatus{Locked: &locked})
+}
@@ -628,6 +628,106 @@ var _ = Describe("Users", func() {
 		})
 	})
 
+	Describe("UserManager#Patch", func() {
+		It("performs PATCH on the user with If-Match set to the version", func() {
+			uaaServer.RouteToHandler("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70"),
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+				ghttp.VerifyHeaderKV("If-Match", "3"),
+				ghttp.VerifyJSON(`{ "name" : { "familyName" : "Aurelius", "givenName" : "Marcus" }}`),
+				ghttp.RespondWith(http.StatusOK, MarcusUserResponse),
+			))
+
+			changes := ScimUser{Name: &ScimUserName{GivenName: "Marcus", FamilyName: "Aurelius"}}
+			user, err := um.Patch("fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", 3, changes)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Username).To(Equal("marcus@stoicism.com"))
+		})
+
+		It("returns error when response is not 200 OK", func() {
+			uaaServer.RouteToHandler("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", ghttp.RespondWith(http.StatusPreconditionFailed, ""))
+
+			_, err := um.Patch("fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", 3, ScimUser{})
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UserManager#PatchCurrent", func() {
+		var user ScimUser
+
+		BeforeEach(func() {
+			user = ScimUser{ID: "fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", Meta: &ScimMetaInfo{Version: 3}}
+		})
+
+		It("patches the version the user was read with", func() {
+			uaaServer.RouteToHandler("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", ghttp.CombineHandlers(
+				ghttp.VerifyHeaderKV("If-Match", "3"),
+				ghttp.VerifyJSON(`{"externalId": "marcus-user"}`),
+				ghttp.RespondWith(http.StatusOK, MarcusUserResponse),
+			))
+
+			patched, err := um.PatchCurrent(user, map[string]string{"externalId": "marcus-user"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(patched.ExternalId).To(Equal("marcus-user"))
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("reads the user again and retries when the version is stale", func() {
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70"),
+					ghttp.VerifyHeaderKV("If-Match", "3"),
+					ghttp.RespondWith(http.StatusPreconditionFailed, `{"error": "scim_resource_conflict"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70"),
+					ghttp.RespondWith(http.StatusOK, `{"id": "fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", "meta": {"version": 4}}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70"),
+					ghttp.VerifyHeaderKV("If-Match", "4"),
+					ghttp.VerifyJSON(`{"externalId": "marcus-user"}`),
+					ghttp.RespondWith(http.StatusOK, MarcusUserResponse),
+				),
+			)
+
+			patched, err := um.PatchCurrent(user, map[string]string{"externalId": "marcus-user"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(patched.Username).To(Equal("marcus@stoicism.com"))
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("gives up after a few attempts", func() {
+			uaaServer.RouteToHandler("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", ghttp.RespondWith(http.StatusPreconditionFailed, ""))
+			uaaServer.RouteToHandler("GET", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", ghttp.RespondWith(http.StatusOK, `{"id": "fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", "meta": {"version": 4}}`))
+
+			_, err := um.PatchCurrent(user, map[string]string{"externalId": "marcus-user"})
+
+			Expect(err).To(BeAssignableToTypeOf(RequestError{}))
+			Expect(err.(RequestError).StatusCode).To(Equal(http.StatusPreconditionFailed))
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(2*PatchAttempts - 1))
+		})
+
+		It("does not retry other errors", func() {
+			uaaServer.RouteToHandler("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", ghttp.RespondWith(http.StatusBadRequest, ""))
+
+			_, err := um.PatchCurrent(user, map[string]string{"externalId": "marcus-user"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("requires the metadata version", func() {
+			_, err := um.PatchCurrent(ScimUser{ID: "fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70"}, map[string]string{})
+
+			Expect(err).To(MatchError("The user did not have expected metadata version."))
+		})
+	})
+
 	Describe("UserManager#Delete", func() {
 		It("performs DELETE with user data and bearer token", func() {
 			uaaServer.RouteToHandler("DELETE", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", ghttp.CombineHandlers(
@@ -676,4 +776,40 @@ var _ = Describe("Users", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Describe("UserManager#SetStatus", func() {
+		It("patches the status of the user", func() {
+			uaaServer.RouteToHandler("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70/status", ghttp.CombineHandlers(
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+				ghttp.VerifyJSON(`{"locked": false}`),
+				ghttp.RespondWith(http.StatusOK, `{"locked": false}`),
+			))
+
+			locked := false
+			err := um.SetStatus("fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", UserStatus{Locked: &locked})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns error when response is not 200 OK", func() {
+			uaaServer.RouteToHandler("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70/status", ghttp.RespondWith(http.StatusBadRequest, ""))
+
+			required := true
+			err := um.SetStatus("fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70", UserStatus{PasswordChangeRequired: &required})
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UserManager#Unlock", func() {
+		It("sets locked to false", func() {
+			uaaServer.RouteToHandler("PATCH", "/Users/fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70/status", ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"locked": false}`),
+				ghttp.RespondWith(http.StatusOK, `{"locked": false}`),
+			))
+
+			Expect(um.Unlock("fb5f32e1-5cb3-49e6-93df-6df9c8c8bd70")).To(Succeed())
+		})
+	})
 })
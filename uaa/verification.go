@@ -0,0 +1,49 @@
+package uaa
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+type verificationLink struct {
+	VerifyLink string `json:"verify_link"`
+}
+
+// GetVerificationLink returns a link with which a user of the "uaa" identity
+// provider verifies their email address. Afterwards the user is sent to the
+// redirect URI, which must be allowed for the client. An empty clientId stands
+// for the client of the active context's token.
+func (um UserManager) GetVerificationLink(userID, clientId, redirectUri string) (string, error) {
+	path := "/Users/" + userID + "/verify-link"
+	query := url.Values{}
+	if clientId != "" {
+		query.Add("client_id", clientId)
+	}
+	query.Add("redirect_uri", redirectUri)
+
+	bytes, err := AuthenticatedRequester{}.Get(um.HttpClient, um.Config, path, query.Encode())
+	if err != nil {
+		return "", err
+	}
+
+	link := verificationLink{}
+	if err := json.Unmarshal(bytes, &link); err != nil {
+		return "", parseError(path, bytes)
+	}
+	return link.VerifyLink, nil
+}
+
+// Verify marks the email address of a user as verified without a link.
+func (um UserManager) Verify(userID string) (ScimUser, error) {
+	path := "/Users/" + userID + "/verify"
+	bytes, err := AuthenticatedRequester{}.Get(um.HttpClient, um.Config, path, "")
+	if err != nil {
+		return ScimUser{}, err
+	}
+
+	user := ScimUser{}
+	if err := json.Unmarshal(bytes, &user); err != nil {
+		return ScimUser{}, parseError(path, bytes)
+	}
+	return user, nil
+}
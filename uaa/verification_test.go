@@ -0,0 +1,87 @@
+package uaa_test
+
+import (
+	"net/http"
+
+	. "code.cloudfoundry.org/uaa-cli/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Verification", func() {
+	var (
+		uaaServer *ghttp.Server
+		um        UserManager
+	)
+
+	BeforeEach(func() {
+		uaaServer = ghttp.NewServer()
+		config := NewConfigWithServerURL(uaaServer.URL())
+		config.AddContext(NewContextWithToken("access_token"))
+		um = UserManager{&http.Client{}, config}
+	})
+
+	AfterEach(func() {
+		uaaServer.Close()
+	})
+
+	Describe("UserManager#GetVerificationLink", func() {
+		It("returns the link", func() {
+			uaaServer.RouteToHandler("GET", "/Users/abcdef/verify-link", ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/Users/abcdef/verify-link", "client_id=app&redirect_uri=https%3A%2F%2Fapp.example.com"),
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+				ghttp.RespondWith(http.StatusOK, `{"verify_link": "https://login.example.com/verify_user?code=abc"}`),
+			))
+
+			link, err := um.GetVerificationLink("abcdef", "app", "https://app.example.com")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(link).To(Equal("https://login.example.com/verify_user?code=abc"))
+		})
+
+		It("leaves out the client id when it is not given", func() {
+			uaaServer.RouteToHandler("GET", "/Users/abcdef/verify-link", ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/Users/abcdef/verify-link", "redirect_uri=https%3A%2F%2Fapp.example.com"),
+				ghttp.RespondWith(http.StatusOK, `{"verify_link": "https://login.example.com/verify_user?code=abc"}`),
+			))
+
+			_, err := um.GetVerificationLink("abcdef", "", "https://app.example.com")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error when the user is already verified", func() {
+			uaaServer.RouteToHandler("GET", "/Users/abcdef/verify-link", ghttp.RespondWith(http.StatusMethodNotAllowed,
+				`{"error": "user_already_verified", "error_description": "UAA user is already verified."}`))
+
+			_, err := um.GetVerificationLink("abcdef", "", "https://app.example.com")
+
+			Expect(err).To(BeAssignableToTypeOf(RequestError{}))
+			Expect(err.(RequestError).Description()).To(Equal("UAA user is already verified."))
+		})
+	})
+
+	Describe("UserManager#Verify", func() {
+		It("verifies the user and returns it", func() {
+			uaaServer.RouteToHandler("GET", "/Users/abcdef/verify", ghttp.CombineHandlers(
+				ghttp.VerifyHeaderKV("Authorization", "bearer access_token"),
+				ghttp.RespondWith(http.StatusOK, `{"id": "abcdef", "userName": "woodstock", "verified": true}`),
+			))
+
+			user, err := um.Verify("abcdef")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Username).To(Equal("woodstock"))
+			Expect(*user.Verified).To(BeTrue())
+		})
+
+		It("returns an error when the response is not 200 OK", func() {
+			uaaServer.RouteToHandler("GET", "/Users/abcdef/verify", ghttp.RespondWith(http.StatusNotFound, ""))
+
+			_, err := um.Verify("abcdef")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -4,12 +4,24 @@ import (
 	"net/url"
 )
 
+// BuildUrl joins path to baseUrl. Paths which are already absolute URLs,
+// such as endpoints from an OpenID Connect discovery document, are returned
+// as they are.
 func BuildUrl(baseUrl, path string) (*url.URL, error) {
+	if pathUrl, err := url.Parse(path); err == nil && pathUrl.IsAbs() {
+		return pathUrl, nil
+	}
+
 	newUrl, err := url.Parse(baseUrl)
 	if err != nil {
 		return nil, err
 	}
 
+	// Keep escaped segments, such as an entity ID in an alias path, escaped.
 	newUrl.Path = path
+	if unescaped, err := url.PathUnescape(path); err == nil && unescaped != path {
+		newUrl.Path = unescaped
+		newUrl.RawPath = path
+	}
 	return newUrl, nil
 }
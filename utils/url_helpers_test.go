@@ -21,5 +21,15 @@ var _ = Describe("UrlHelpers", func() {
 			url, _ = utils.BuildUrl("http://localhost:8080", "/foo")
 			Expect(url.String()).To(Equal("http://localhost:8080/foo"))
 		})
+
+		It("keeps escaped path segments escaped", func() {
+			url, _ := utils.BuildUrl("http://localhost:8080", "/oauth/token/alias/https%3A%2F%2Fsp.example.com%2Fsaml")
+			Expect(url.String()).To(Equal("http://localhost:8080/oauth/token/alias/https%3A%2F%2Fsp.example.com%2Fsaml"))
+		})
+
+		It("uses absolute urls as they are", func() {
+			url, _ := utils.BuildUrl("http://localhost:8080", "https://login.example.com/oauth/token")
+			Expect(url.String()).To(Equal("https://login.example.com/oauth/token"))
+		})
 	})
 })